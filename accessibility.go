@@ -0,0 +1,173 @@
+package scraper
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// AccessibilityIssueKind is a kind of statically detectable accessibility problem
+type AccessibilityIssueKind string
+
+const (
+	IssueMissingAlt   AccessibilityIssueKind = "missing-alt"
+	IssueEmptyLink    AccessibilityIssueKind = "empty-link"
+	IssueEmptyButton  AccessibilityIssueKind = "empty-button"
+	IssueMissingLabel AccessibilityIssueKind = "missing-label"
+	IssueHeadingJump  AccessibilityIssueKind = "heading-order-jump"
+)
+
+type (
+	// AccessibilityReport lists accessibility issues of a document in document order
+	AccessibilityReport struct {
+		URL    string
+		Issues []AccessibilityIssue
+	}
+
+	AccessibilityIssue struct {
+		Kind   AccessibilityIssueKind
+		Node   *html.Node
+		Detail string
+	}
+)
+
+// Count returns the number of issues of the kind
+func (r AccessibilityReport) Count(kind AccessibilityIssueKind) int {
+	var n int
+	for _, issue := range r.Issues {
+		if issue.Kind == kind {
+			n++
+		}
+	}
+
+	return n
+}
+
+// AccessibilityAudit reports obvious accessibility issues detectable without rendering the page:
+// images without alt, links and buttons without an accessible name, form controls without a label
+// and headings skipping a level. Issues of a frozen Scraper refer to detached copies of the nodes.
+func (s *Scraper) AccessibilityAudit() AccessibilityReport {
+	var report AccessibilityReport
+	if s.url != nil {
+		report.URL = s.url.String()
+	}
+
+	labelled := make(map[string]bool)
+	for _, l := range elements(s.doc, "label") {
+		if id, ok := attr(l, "for"); ok {
+			labelled[id] = true
+		}
+	}
+
+	var lastHeading int
+	walk(s.doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		add := func(kind AccessibilityIssueKind, detail string) {
+			report.Issues = append(report.Issues, AccessibilityIssue{Kind: kind, Node: n, Detail: detail})
+		}
+
+		switch n.Data {
+		case "img":
+			if _, ok := attr(n, "alt"); !ok {
+				src, _ := attr(n, "src")
+				add(IssueMissingAlt, fmt.Sprintf("img [%s] has no alt attribute", src))
+			}
+		case "a":
+			if href, ok := attr(n, "href"); ok && !hasAccessibleName(n) {
+				add(IssueEmptyLink, fmt.Sprintf("link [%s] has no text", href))
+			}
+		case "button":
+			if !hasAccessibleName(n) {
+				add(IssueEmptyButton, "button has no text")
+			}
+		case "input":
+			inputType, _ := attr(n, "type")
+			switch strings.ToLower(strings.TrimSpace(inputType)) {
+			case "hidden":
+			case "submit", "reset":
+				// without a value browsers label them Submit and Reset, only an empty value leaves them blank
+				if value, ok := attr(n, "value"); ok && strings.TrimSpace(value) == "" && !hasAccessibleName(n) {
+					add(IssueEmptyButton, fmt.Sprintf("input of type [%s] has an empty value", inputType))
+				}
+			case "image":
+				if alt, _ := attr(n, "alt"); strings.TrimSpace(alt) == "" && !hasAccessibleName(n) {
+					src, _ := attr(n, "src")
+					add(IssueEmptyButton, fmt.Sprintf("input of type [image] with src [%s] has no alt text", src))
+				}
+			case "button":
+				if value, _ := attr(n, "value"); strings.TrimSpace(value) == "" && !hasAccessibleName(n) {
+					add(IssueEmptyButton, fmt.Sprintf("input of type [%s] has no value", inputType))
+				}
+			default:
+				if !isLabelled(n, labelled) {
+					add(IssueMissingLabel, "input has no label")
+				}
+			}
+		case "select", "textarea":
+			if !isLabelled(n, labelled) {
+				add(IssueMissingLabel, n.Data+" has no label")
+			}
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			level := int(n.Data[1] - '0')
+			if lastHeading != 0 && level > lastHeading+1 {
+				add(IssueHeadingJump, fmt.Sprintf("h%d follows h%d", level, lastHeading))
+			}
+			lastHeading = level
+		}
+
+		return true
+	})
+	if s.frozen {
+		for i := range report.Issues {
+			report.Issues[i].Node = cloneNode(report.Issues[i].Node)
+		}
+	}
+
+	return report
+}
+
+// hasAccessibleName reports whether n has text, aria labelling, title or an image with alt text
+func hasAccessibleName(n *html.Node) bool {
+	for _, key := range []string{"aria-label", "aria-labelledby", "title"} {
+		if v, _ := attr(n, key); strings.TrimSpace(v) != "" {
+			return true
+		}
+	}
+	if strings.TrimSpace(textContent(n)) != "" {
+		return true
+	}
+
+	var found bool
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.ElementNode && c.Data == "img" {
+			if alt, _ := attr(c, "alt"); strings.TrimSpace(alt) != "" {
+				found = true
+			}
+		}
+		return !found
+	})
+
+	return found
+}
+
+func isLabelled(n *html.Node, labelled map[string]bool) bool {
+	if id, ok := attr(n, "id"); ok && labelled[id] {
+		return true
+	}
+	for _, key := range []string{"aria-label", "aria-labelledby", "title"} {
+		if v, _ := attr(n, key); strings.TrimSpace(v) != "" {
+			return true
+		}
+	}
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Type == html.ElementNode && p.Data == "label" {
+			return true
+		}
+	}
+
+	return false
+}
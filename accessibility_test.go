@@ -0,0 +1,70 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScraperAccessibilityAudit(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<h1>Title</h1>
+<h3>Skipped</h3>
+<img src="/a.png">
+<img src="/b.png" alt="">
+<a href="/empty"></a>
+<a href="/icon"><img src="/c.png" alt="Cart"></a>
+<a href="/text">Text</a>
+<button></button>
+<button aria-label="Close"></button>
+<input type="submit">
+<input type="reset">
+<input type="submit" value=" ">
+<input type="button">
+<input type="image" src="/search.png" alt="Search">
+<input type="image" src="/go.png">
+<input type="hidden" name="token">
+<label for="q">Search</label><input id="q">
+<label>Name <input name="name"></label>
+<input name="email">
+<select></select>
+</body></html>`)
+
+	got := s.AccessibilityAudit()
+
+	var kinds []AccessibilityIssueKind
+	for _, issue := range got.Issues {
+		kinds = append(kinds, issue.Kind)
+	}
+	want := []AccessibilityIssueKind{
+		IssueHeadingJump,
+		IssueMissingAlt,
+		IssueEmptyLink,
+		IssueEmptyButton,
+		IssueEmptyButton,
+		IssueEmptyButton,
+		IssueEmptyButton,
+		IssueMissingLabel,
+		IssueMissingLabel,
+	}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("AccessibilityAudit() got = %v, want %v", kinds, want)
+	}
+	if got.Count(IssueEmptyButton) != 4 {
+		t.Errorf("Count() got = %d, want %d", got.Count(IssueEmptyButton), 4)
+	}
+	var details []string
+	for _, issue := range got.Issues {
+		if issue.Kind == IssueEmptyButton {
+			details = append(details, issue.Detail)
+		}
+	}
+	wantDetails := []string{
+		"button has no text",
+		"input of type [submit] has an empty value",
+		"input of type [button] has no value",
+		"input of type [image] with src [/go.png] has no alt text",
+	}
+	if !reflect.DeepEqual(details, wantDetails) {
+		t.Errorf("AccessibilityAudit() empty button details got = %v, want %v", details, wantDetails)
+	}
+}
@@ -0,0 +1,80 @@
+package scraper
+
+import (
+	"errors"
+	"log"
+	"slices"
+	"strings"
+)
+
+// ErrNoAMP is returned by AMPURL when the document doesn't link an AMP version
+var ErrNoAMP = errors.New("page has no AMP version")
+
+// WithPreferAMP makes New scrape the AMP version of the page linked with <link rel="amphtml"> instead of the page.
+// AMP pages are usually lighter and rendered statically. The page itself is returned if it has no AMP version
+// or the AMP version can't be fetched.
+func WithPreferAMP() Option {
+	return func(o *options) error {
+		o.preferAMP = true
+		return nil
+	}
+}
+
+// IsAMP reports whether the document is an AMP page, marked with the amp or ⚡ attribute of the html element
+func (s *Scraper) IsAMP() bool {
+	for _, n := range elements(s.doc, "html") {
+		if _, ok := attr(n, "amp"); ok {
+			return true
+		}
+		if _, ok := attr(n, "⚡"); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AMPURL returns the absolute URL of the AMP version of the document or ErrNoAMP if there is none
+func (s *Scraper) AMPURL() (string, error) {
+	for _, l := range elements(s.doc, "link") {
+		rel, _ := attr(l, "rel")
+		href, _ := attr(l, "href")
+		if !hasToken(rel, "amphtml") || strings.TrimSpace(href) == "" {
+			continue
+		}
+		return s.absoluteURL(strings.TrimSpace(href))
+	}
+
+	return "", ErrNoAMP
+}
+
+// AMP fetches and parses the AMP version of the document, to scrape it in addition to the page
+func (s *Scraper) AMP(client HTTPClient, opts ...Option) (*Scraper, error) {
+	ampURL, err := s.AMPURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(ampURL, client, opts...)
+}
+
+// preferAMP returns the AMP version of s if it has one, otherwise s
+func (s *Scraper) preferAMP(client HTTPClient, opts []Option) *Scraper {
+	if s.IsAMP() {
+		return s
+	}
+	if _, err := s.AMPURL(); err != nil {
+		return s
+	}
+
+	amp, err := s.AMP(client, slices.Concat(opts, []Option{func(o *options) error {
+		o.preferAMP = false
+		return nil
+	}})...)
+	if err != nil {
+		log.Printf("fetch AMP version error: %s. Scraping the page itself", err.Error())
+		return s
+	}
+
+	return amp
+}
@@ -0,0 +1,103 @@
+package scraper
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestScraperAMP(t *testing.T) {
+	client := httpClientWithPages{
+		"https://news.test/article":     `<html><head><link rel="amphtml" href="/article/amp"></head><body><h1>Full</h1></body></html>`,
+		"https://news.test/article/amp": `<html ⚡><head><link rel="amphtml" href="/article/amp"></head><body><h1>AMP</h1></body></html>`,
+		"https://news.test/broken":      `<html><head><link rel="amphtml" href="/missing"></head><body><h1>Broken</h1></body></html>`,
+		"https://news.test/plain":       `<html amp><body><h1>Plain</h1></body></html>`,
+	}
+
+	tests := []struct {
+		name      string
+		url       string
+		opts      []Option
+		wantTitle string
+		wantAMP   string
+		wantErr   error
+	}{
+		{
+			name:      "page",
+			url:       "https://news.test/article",
+			wantTitle: "Full",
+			wantAMP:   "https://news.test/article/amp",
+		},
+		{
+			name:      "prefer AMP",
+			url:       "https://news.test/article",
+			opts:      []Option{WithPreferAMP()},
+			wantTitle: "AMP",
+			wantAMP:   "https://news.test/article/amp",
+		},
+		{
+			name:      "prefer AMP of AMP page",
+			url:       "https://news.test/article/amp",
+			opts:      []Option{WithPreferAMP()},
+			wantTitle: "AMP",
+			wantAMP:   "https://news.test/article/amp",
+		},
+		{
+			name:      "prefer AMP falls back to page",
+			url:       "https://news.test/broken",
+			opts:      []Option{WithPreferAMP()},
+			wantTitle: "Broken",
+			wantAMP:   "https://news.test/missing",
+		},
+		{
+			name:      "no AMP version",
+			url:       "https://news.test/plain",
+			opts:      []Option{WithPreferAMP()},
+			wantTitle: "Plain",
+			wantErr:   ErrNoAMP,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.url, client, tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if got, _ := s.GetValue("/html/body/h1/text"); got != tt.wantTitle {
+				t.Errorf("title got = %q, want %q", got, tt.wantTitle)
+			}
+			got, err := s.AMPURL()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AMPURL() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.wantAMP {
+				t.Errorf("AMPURL() got = %q, want %q", got, tt.wantAMP)
+			}
+		})
+	}
+}
+
+func TestScraperAMPInAddition(t *testing.T) {
+	client := httpClientWithPages{
+		"https://news.test/article":     `<html><head><link rel="amphtml" href="amp"></head></html>`,
+		"https://news.test/amp":         `<html amp><body><h1>AMP</h1></body></html>`,
+		"https://news.test/article/amp": `<html amp><body><h1>Wrong</h1></body></html>`,
+	}
+	s, err := New("https://news.test/article", client)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if s.IsAMP() {
+		t.Errorf("IsAMP() got = true for the page")
+	}
+
+	amp, err := s.AMP(client)
+	if err != nil {
+		t.Fatalf("AMP() error = %v", err)
+	}
+	if !amp.IsAMP() {
+		t.Errorf("IsAMP() got = false for the AMP version")
+	}
+	if got, _ := amp.GetValue("/html/body/h1/text"); got != "AMP" {
+		t.Errorf("title got = %q, want %q", got, "AMP")
+	}
+}
@@ -0,0 +1,159 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// AuditRecord is a single line of the audit log written by the client returned from NewAuditHTTPClient
+	AuditRecord struct {
+		URL       string    `json:"url"`
+		Timestamp time.Time `json:"timestamp"`
+		Status    int       `json:"status,omitempty"`
+		Bytes     int64     `json:"bytes"`
+		Proxy     string    `json:"proxy,omitempty"`
+		UserAgent string    `json:"userAgent,omitempty"`
+		RequestID string    `json:"requestId,omitempty"`
+		Error     string    `json:"error,omitempty"`
+	}
+
+	// AuditOption configures the client created by NewAuditHTTPClient
+	AuditOption func(*auditHTTPClient) error
+
+	auditHTTPClient struct {
+		client HTTPClient
+		clock  Clock
+
+		mu  sync.Mutex
+		enc *json.Encoder
+	}
+
+	auditBody struct {
+		io.ReadCloser
+		record AuditRecord
+		audit  *auditHTTPClient
+		once   sync.Once
+	}
+
+	// chosenProxyKey is the context key of the proxy chosen by the transport of the retry client for a request
+	chosenProxyKey struct{}
+)
+
+// NewAuditHTTPClient wraps client and writes one JSON line per request to w.
+// The record is written when the response body is closed, so Bytes reflects what was actually read.
+// Failed requests are written immediately with Error set.
+// Proxy is the proxy the transport of a client created by NewHTTPClientWithRetry chose for the request,
+// including proxies of geo profiles and host scopes. It is empty for other clients.
+func NewAuditHTTPClient(client HTTPClient, w io.Writer, opts ...AuditOption) (HTTPClient, error) {
+	if client == nil {
+		return nil, errors.New("client should be not nil")
+	}
+	if w == nil {
+		return nil, errors.New("writer should be not nil")
+	}
+
+	c := &auditHTTPClient{
+		client: client,
+		clock:  SystemClock,
+		enc:    json.NewEncoder(w),
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, fmt.Errorf("apply audit option: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+func (c *auditHTTPClient) Get(url *url.URL) (*http.Response, error) {
+	record := AuditRecord{Timestamp: c.clock.Now().UTC()}
+	if url != nil {
+		record.URL = url.String()
+	}
+
+	resp, err := c.client.Get(url)
+	if err != nil {
+		var idErr *RequestIDError
+		if errors.As(err, &idErr) {
+			record.RequestID = idErr.RequestID
+		}
+		record.Error = err.Error()
+		c.write(record)
+		return nil, err
+	}
+
+	record.Status = resp.StatusCode
+	if req := resp.Request; req != nil {
+		record.URL = req.URL.String()
+		record.UserAgent = req.Header.Get("User-Agent")
+		record.RequestID, _ = RequestIDFromContext(req.Context())
+		if proxy := chosenProxy(req.Context()); proxy != nil {
+			record.Proxy = proxy.Redacted()
+		}
+	}
+
+	if resp.Body == nil {
+		c.write(record)
+		return resp, nil
+	}
+	resp.Body = &auditBody{ReadCloser: resp.Body, record: record, audit: c}
+
+	return resp, nil
+}
+
+// withChosenProxy returns req with a holder recordProxy stores the proxy chosen for the request to,
+// redirects made by http.Client share the context, so the holder ends up with the proxy of the last hop
+func withChosenProxy(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), chosenProxyKey{}, new(atomic.Pointer[url.URL])))
+}
+
+// recordProxy wraps the proxy function of a transport, so the proxy chosen for a request is stored to its holder
+func recordProxy(proxy func(*http.Request) (*url.URL, error)) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		u, err := proxy(req)
+		if holder, ok := req.Context().Value(chosenProxyKey{}).(*atomic.Pointer[url.URL]); ok && err == nil {
+			holder.Store(u)
+		}
+		return u, err
+	}
+}
+
+// chosenProxy returns the proxy stored by recordProxy, nil if the request was made directly or by another client
+func chosenProxy(ctx context.Context) *url.URL {
+	if holder, ok := ctx.Value(chosenProxyKey{}).(*atomic.Pointer[url.URL]); ok {
+		return holder.Load()
+	}
+	return nil
+}
+
+func (c *auditHTTPClient) write(record AuditRecord) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.enc.Encode(record); err != nil {
+		log.Printf("write audit record error: %s", err.Error())
+	}
+}
+
+func (b *auditBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.record.Bytes += int64(n)
+	return n, err
+}
+
+func (b *auditBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.audit.write(b.record) })
+	return err
+}
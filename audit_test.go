@@ -0,0 +1,120 @@
+package scraper
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+)
+
+func TestAuditHTTPClientGet(t *testing.T) {
+	body, _ := os.ReadFile("./test-data/correct.html.txt")
+	target, _ := url.Parse("https://someAddress")
+
+	tests := []struct {
+		name    string
+		client  HTTPClient
+		want    AuditRecord
+		wantErr bool
+	}{
+		{
+			name:   "correct",
+			client: &httpClientWithoutError{},
+			want:   AuditRecord{URL: "https://someAddress", Status: 200, Bytes: int64(len(body))},
+		},
+		{
+			name:    "error during going GET request",
+			client:  &httpClientWithError{},
+			want:    AuditRecord{URL: "https://someAddress", Error: "error occurred"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			client, err := NewAuditHTTPClient(tt.client, &buf)
+			if err != nil {
+				t.Fatalf("NewAuditHTTPClient() error = %v", err)
+			}
+
+			resp, err := client.Get(target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if resp != nil {
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+				_ = resp.Body.Close()
+			}
+
+			var got AuditRecord
+			dec := json.NewDecoder(&buf)
+			if err = dec.Decode(&got); err != nil {
+				t.Fatalf("decode audit record: %v", err)
+			}
+			if dec.More() {
+				t.Errorf("audit log has more than one record")
+			}
+			if got.Timestamp.IsZero() {
+				t.Errorf("audit record timestamp is zero")
+			}
+			got.Timestamp = tt.want.Timestamp
+			if got != tt.want {
+				t.Errorf("audit record got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditHTTPClientProxy(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<p>ok</p>"))
+	})
+	proxy := httptest.NewServer(handler)
+	defer proxy.Close()
+	direct := httptest.NewServer(handler)
+	defer direct.Close()
+
+	client, err := NewHTTPClientWithRetry(0, 0,
+		WithHostOptions("*.test", WithGeoProfile(GeoProfile{Name: "proxy", Proxy: proxy.URL})),
+	)
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+	var buf bytes.Buffer
+	audited, err := NewAuditHTTPClient(client, &buf)
+	if err != nil {
+		t.Fatalf("NewAuditHTTPClient() error = %v", err)
+	}
+
+	dec := json.NewDecoder(&buf)
+	for target, want := range map[string]string{"http://shop.test/": proxy.URL, direct.URL: ""} {
+		u, _ := url.Parse(target)
+		resp, err := audited.Get(u)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		_ = resp.Body.Close()
+
+		var record AuditRecord
+		if err = dec.Decode(&record); err != nil {
+			t.Fatalf("decode audit record: %v", err)
+		}
+		if record.Proxy != want {
+			t.Errorf("audit record proxy for %s got = %q, want %q", target, record.Proxy, want)
+		}
+	}
+}
+
+func TestNewAuditHTTPClient(t *testing.T) {
+	if _, err := NewAuditHTTPClient(nil, io.Discard); err == nil {
+		t.Errorf("NewAuditHTTPClient() with nil client expected error")
+	}
+	if _, err := NewAuditHTTPClient(&httpClientWithoutError{}, nil); err == nil {
+		t.Errorf("NewAuditHTTPClient() with nil writer expected error")
+	}
+}
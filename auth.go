@@ -0,0 +1,255 @@
+package scraper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// tokenExpiryMargin is how long before its expiry a token is refreshed, so it doesn't expire on the way
+const tokenExpiryMargin = 10 * time.Second
+
+type (
+	// Authenticator adds credentials to a request. It is called before every attempt,
+	// so signatures covering the time of the request stay valid on retries.
+	Authenticator interface {
+		Authenticate(req *http.Request) error
+	}
+
+	// AuthFunc is an Authenticator calling the function, for example a signer of requests to internal endpoints
+	AuthFunc func(req *http.Request) error
+
+	// TokenRefresher returns a new token and its expiry time, zero if the token doesn't expire
+	TokenRefresher func() (token string, expiry time.Time, err error)
+
+	// BearerAuth sets a bearer token obtained by a TokenRefresher. The token is reused until it expires
+	// or the server responds with 401 Unauthorized. It is safe for concurrent use.
+	BearerAuth struct {
+		refresh TokenRefresher
+		clock   Clock
+
+		mu     sync.Mutex
+		token  string
+		expiry time.Time
+	}
+
+	// hostAuth is an Authenticator used for hosts matching pattern
+	hostAuth struct {
+		pattern string
+		auth    Authenticator
+	}
+
+	// invalidator is implemented by authenticators caching credentials the server may reject
+	invalidator interface {
+		invalidate()
+	}
+)
+
+// WithAuth makes the client authenticate requests to hosts matching hostPattern, like "api.example.com" or
+// "*.example.com" or "*" for any host. Patterns are checked in the order they were added and the first
+// matching one is used.
+func WithAuth(hostPattern string, auth Authenticator) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if auth == nil {
+			return errors.New("authenticator should be not nil")
+		}
+		if err := validateHostPattern(hostPattern); err != nil {
+			return err
+		}
+		c.auth = append(c.auth, hostAuth{pattern: strings.ToLower(hostPattern), auth: auth})
+		return nil
+	}
+}
+
+func (f AuthFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// BasicAuth returns an Authenticator setting HTTP basic authentication
+func BasicAuth(username, password string) Authenticator {
+	return AuthFunc(func(req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	})
+}
+
+// BearerToken returns an Authenticator setting a static bearer token
+func BearerToken(token string) Authenticator {
+	return AuthFunc(func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// NewBearerAuth returns a BearerAuth with tokens of refresh, clock tells when they expire and is SystemClock if nil
+func NewBearerAuth(refresh TokenRefresher, clock Clock) (*BearerAuth, error) {
+	if refresh == nil {
+		return nil, errors.New("token refresher should be not nil")
+	}
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	return &BearerAuth{refresh: refresh, clock: clock}, nil
+}
+
+// NewOAuth2ClientCredentials returns a BearerAuth obtaining tokens from tokenURL with the OAuth2 client
+// credentials grant, clock tells when tokens expire and is SystemClock if nil
+func NewOAuth2ClientCredentials(tokenURL, clientID, clientSecret string, clock Clock, scopes ...string) (*BearerAuth, error) {
+	u, err := url.Parse(tokenURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse token url [%s]: %w", tokenURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, errors.New("token url should be an absolute URL")
+	}
+	if clientID == "" {
+		return nil, errors.New("client id should be not empty")
+	}
+
+	if clock == nil {
+		clock = SystemClock
+	}
+	client := cleanhttp.DefaultClient()
+	return NewBearerAuth(func() (string, time.Time, error) {
+		return fetchClientCredentialsToken(client, clock, u.String(), clientID, clientSecret, scopes)
+	}, clock)
+}
+
+func (a *BearerAuth) Authenticate(req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == "" || (!a.expiry.IsZero() && !a.clock.Now().Before(a.expiry.Add(-tokenExpiryMargin))) {
+		token, expiry, err := a.refresh()
+		if err != nil {
+			return fmt.Errorf("refresh token: %w", err)
+		}
+		if token == "" {
+			return errors.New("refreshed token is empty")
+		}
+		a.token, a.expiry = token, expiry
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+
+	return nil
+}
+
+func (a *BearerAuth) invalidate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.token = ""
+}
+
+func fetchClientCredentialsToken(client *http.Client, clock Clock, tokenURL, clientID, clientSecret string, scopes []string) (string, time.Time, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(scopes) != 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+	req, err := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+
+	requested := clock.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("perform token request: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Printf("resp body close error: %s", closeErr.Error())
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("token endpoint status code is not 200: %d", resp.StatusCode)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return "", time.Time{}, fmt.Errorf("decode token response: %w", err)
+	}
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, "bearer") {
+		return "", time.Time{}, fmt.Errorf("token type [%s] is not bearer", token.TokenType)
+	}
+
+	var expiry time.Time
+	if token.ExpiresIn > 0 {
+		expiry = requested.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	return token.AccessToken, expiry, nil
+}
+
+// authenticate adds credentials of the first authenticator matching the host of req
+// and then signs it with the first matching signer
+func (c *httpClientWithRetry) authenticate(req *http.Request) error {
+	if a := c.authFor(req.URL); a != nil {
+		if err := c.callAuthenticator(a, req); err != nil {
+			return err
+		}
+	}
+	if s := matchingAuth(c.signers, req.URL); s != nil {
+		return c.callAuthenticator(s, req)
+	}
+
+	return nil
+}
+
+// callAuthenticator authenticates req with a, authenticators depending on the time get the time of the client clock
+func (c *httpClientWithRetry) callAuthenticator(a Authenticator, req *http.Request) error {
+	if clocked, ok := a.(clockedAuthenticator); ok {
+		return clocked.authenticateAt(req, c.clock.Now())
+	}
+
+	return a.Authenticate(req)
+}
+
+// invalidateAuth drops cached credentials for the host of req, false if there are none to drop
+func (c *httpClientWithRetry) invalidateAuth(req *http.Request) bool {
+	i, ok := c.authFor(req.URL).(invalidator)
+	if !ok {
+		return false
+	}
+	i.invalidate()
+
+	return true
+}
+
+// authFor returns the authenticator for the host of u, authenticators of the host scope are checked first
+func (c *httpClientWithRetry) authFor(u *url.URL) Authenticator {
+	if h := c.hostConfigFor(u); h != nil {
+		if a := matchingAuth(h.auth, u); a != nil {
+			return a
+		}
+	}
+
+	return matchingAuth(c.auth, u)
+}
+
+// matchingAuth returns the first authenticator of auth with a pattern matching the host of u, nil if there is none
+func matchingAuth(auth []hostAuth, u *url.URL) Authenticator {
+	for _, a := range auth {
+		if matchHost(a.pattern, u.Hostname()) {
+			return a.auth
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package scraper
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithAuth(t *testing.T) {
+	var gotAuth, gotSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth, gotSignature = r.Header.Get("Authorization"), r.Header.Get("X-Signature")
+		_, _ = w.Write([]byte("<p>ok</p>"))
+	}))
+	defer server.Close()
+
+	signer := AuthFunc(func(req *http.Request) error {
+		req.Header.Set("X-Signature", "signed "+req.URL.Path)
+		return nil
+	})
+
+	tests := []struct {
+		name          string
+		opts          []ClientOption
+		wantAuth      string
+		wantSignature string
+	}{
+		{
+			name:     "basic",
+			opts:     []ClientOption{WithAuth("127.0.0.*", BasicAuth("user", "pass"))},
+			wantAuth: "Basic dXNlcjpwYXNz",
+		},
+		{
+			name:     "first matching pattern",
+			opts:     []ClientOption{WithAuth("*.example.com", BasicAuth("user", "pass")), WithAuth("*", BearerToken("token"))},
+			wantAuth: "Bearer token",
+		},
+		{
+			name:          "signer",
+			opts:          []ClientOption{WithAuth("127.0.0.1", signer)},
+			wantSignature: "signed /page",
+		},
+		{
+			name: "no matching pattern",
+			opts: []ClientOption{WithAuth("example.com", BearerToken("token"))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewHTTPClientWithRetry(0, 0, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+			}
+			if _, err = New(server.URL+"/page", client); err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if gotAuth != tt.wantAuth || gotSignature != tt.wantSignature {
+				t.Errorf("credentials got = %q, %q, want %q, %q", gotAuth, gotSignature, tt.wantAuth, tt.wantSignature)
+			}
+		})
+	}
+}
+
+func TestWithAuthInvalid(t *testing.T) {
+	if _, err := NewHTTPClientWithRetry(0, 0, WithAuth("*", nil)); err == nil {
+		t.Errorf("NewHTTPClientWithRetry() expected error for nil authenticator")
+	}
+	if _, err := NewHTTPClientWithRetry(0, 0, WithAuth("[", BearerToken("token"))); err == nil {
+		t.Errorf("NewHTTPClientWithRetry() expected error for malformed host pattern")
+	}
+	if _, err := NewBearerAuth(nil, nil); err == nil {
+		t.Errorf("NewBearerAuth() expected error for nil refresher")
+	}
+}
+
+func TestBearerAuthRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer revoked" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("<p>ok</p>"))
+	}))
+	defer server.Close()
+
+	var refreshes int
+	tokens := []string{"revoked", "valid"}
+	auth, err := NewBearerAuth(func() (string, time.Time, error) {
+		if refreshes == len(tokens) {
+			return "", time.Time{}, errors.New("no more tokens")
+		}
+		refreshes++
+		return tokens[refreshes-1], time.Now().Add(time.Hour), nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewBearerAuth() error = %v", err)
+	}
+	client, err := NewHTTPClientWithRetry(0, 0, WithAuth("*", auth))
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err = New(server.URL, client); err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+	}
+	if refreshes != 2 {
+		t.Errorf("refreshes got = %d, want 2", refreshes)
+	}
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	expiring, _ := NewBearerAuth(func() (string, time.Time, error) {
+		refreshes++
+		return "valid", now.Add(tokenExpiryMargin + time.Minute), nil
+	}, ClockFunc(func() time.Time { return now }))
+	req := &http.Request{Header: make(http.Header)}
+	refreshes = 0
+	for _, advance := range []time.Duration{0, time.Minute / 2, time.Minute} {
+		now = now.Add(advance)
+		if err = expiring.Authenticate(req); err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+	}
+	if refreshes != 2 {
+		t.Errorf("refreshes of expiring token got = %d, want 2", refreshes)
+	}
+}
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	var tokenRequests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		id, secret, _ := r.BasicAuth()
+		if r.Method != http.MethodPost || id != "client" || secret != "secret" ||
+			r.FormValue("grant_type") != "client_credentials" || r.FormValue("scope") != "read write" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"issued","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer issued" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("<p>ok</p>"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	auth, err := NewOAuth2ClientCredentials(server.URL+"/token", "client", "secret", nil, "read", "write")
+	if err != nil {
+		t.Fatalf("NewOAuth2ClientCredentials() error = %v", err)
+	}
+	client, err := NewHTTPClientWithRetry(0, 0, WithAuth("*", auth))
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = New(server.URL+"/page", client); err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("token requests got = %d, want 1", tokenRequests)
+	}
+
+	wrong, _ := NewOAuth2ClientCredentials(server.URL+"/token", "client", "wrong", nil)
+	client, _ = NewHTTPClientWithRetry(0, 0, WithAuth("*", wrong))
+	if _, err = New(server.URL+"/page", client); err == nil {
+		t.Errorf("New() expected error for rejected client credentials")
+	}
+	if _, err = NewOAuth2ClientCredentials("/token", "client", "secret", nil); err == nil {
+		t.Errorf("NewOAuth2ClientCredentials() expected error for relative token url")
+	}
+}
@@ -0,0 +1,115 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/net/html"
+)
+
+type (
+	// BatchResult is the result of a single path of GetValueBatch
+	BatchResult struct {
+		Value string
+		Err   error
+	}
+
+	// batchPlan is a trie of path steps, every node is resolved once for all paths sharing its prefix
+	batchPlan struct {
+		step     PathStep
+		key      string
+		children []*batchPlan
+		targets  []int
+	}
+)
+
+// GetValueBatch returns GetValue results for all fullXPaths in the same order. Paths are grouped by common prefixes,
+// so shared ancestors are resolved once and only diverging suffixes are evaluated.
+func (s *Scraper) GetValueBatch(fullXPaths []string) []BatchResult {
+	results := make([]BatchResult, len(fullXPaths))
+	plan := &batchPlan{}
+	var misses []int
+
+	for i, fullXPath := range fullXPaths {
+		if s.cache != nil {
+			if entry, ok := s.cache.get(extractionKey{hash: s.hash, path: fullXPath}); ok {
+				results[i] = BatchResult{Value: entry.value, Err: entry.err}
+				continue
+			}
+			misses = append(misses, i)
+		}
+
+		paths, err := s.parsePaths(fullXPath)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		if len(paths) > 1 {
+			// branches of a union may match in any order, so they are not shared with other paths
+			results[i].Value, results[i].Err = s.getValue(context.Background(), fullXPath)
+			continue
+		}
+		plan.add(paths[0], i)
+	}
+
+	plan.resolve([]*html.Node{s.doc}, false, nil, results)
+
+	for _, i := range misses {
+		s.cache.put(extractionKey{hash: s.hash, path: fullXPaths[i]}, results[i].Value, results[i].Err)
+	}
+
+	return results
+}
+
+func (p *batchPlan) add(path []PathStep, target int) {
+	for _, step := range path {
+		p = p.child(step)
+	}
+	p.targets = append(p.targets, target)
+}
+
+// child returns the child of p for step, adding it if it does not exist.
+// Paths rarely diverge much at one level, so children are searched linearly.
+func (p *batchPlan) child(step PathStep) *batchPlan {
+	for _, c := range p.children {
+		if c.key == step.String() {
+			return c
+		}
+	}
+	c := &batchPlan{step: step, key: step.String()}
+	p.children = append(p.children, c)
+
+	return c
+}
+
+// resolve fills results of the paths ending at p and its descendants, nodes are the nodes p resolved to and nested
+// tells whether they may contain descendants of each other, or nodes are nil with err if p could not be resolved
+func (p *batchPlan) resolve(nodes []*html.Node, nested bool, err error, results []BatchResult) {
+	values := nodes
+	// text is joined only where a path ends, as findNodes does for the last step
+	if len(p.targets) != 0 && p.step.Tag == textFunction {
+		values = joinText(nodes)
+	}
+	for _, i := range p.targets {
+		switch {
+		case err != nil:
+			results[i].Err = err
+		case len(values) == 0:
+			results[i].Err = errors.New("element not found")
+		default:
+			results[i].Value, results[i].Err = nodeValue(values[0])
+		}
+	}
+
+	for _, c := range p.children {
+		if err != nil {
+			c.resolve(nil, false, err, results)
+			continue
+		}
+		children, childNested, childErr := findStep(context.Background(), c.step, nodes, nested)
+		if childErr == nil && len(children) == 0 {
+			childErr = errors.New("element not found")
+		}
+		c.resolve(children, childNested, childErr, results)
+	}
+}
@@ -0,0 +1,73 @@
+package scraper
+
+import "testing"
+
+func TestScraperGetValueBatch(t *testing.T) {
+	s, err := New("https://someAddress", &httpClientWithoutError{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	paths := []string{
+		"/html/body/div[1]/div[1]/div[1]/div[2]/div[3]/div/div[2]/div/div[1]/div[1]/div/span[1]/span/span/span/span/span/span/span/span/span/span/span/span/text",
+		"/html",
+		"/html/body/div[1]/div[1]/div[1]/div[2]/div[3]/div/div[2]/div/div[1]/div[1]/div/span[1]/span/span/div",
+		"html/body",
+		"/html/7956ody/div[1]",
+		"/html/head/meta[@property=\"og:url\"]/@content",
+		"/html/head/title/text()",
+		"//table | /html/head/title/text()",
+		"/html/body/div[1]/div[1]/div[1]/div[2]/div[3]/div/div[2]/div/div[1]/div[1]/div/span[1]/span/span/span/span/span/span/span/span/span/span/span/span/text",
+	}
+
+	got := s.GetValueBatch(paths)
+	if len(got) != len(paths) {
+		t.Fatalf("GetValueBatch() got %d results, want %d", len(got), len(paths))
+	}
+	for i, path := range paths {
+		want, wantErr := s.GetValue(path)
+		if got[i].Value != want || (got[i].Err != nil) != (wantErr != nil) {
+			t.Errorf("GetValueBatch()[%d] got = %+v, want value %q and error %v", i, got[i], want, wantErr)
+		}
+	}
+}
+
+func TestScraperGetValueBatchNested(t *testing.T) {
+	s := newTestScraper(t, "<div><div><span>1</span></div><span>2</span>x<b>y</b>z</div>")
+
+	paths := []string{
+		"//div/span/text",
+		"//div/span",
+		"//div/span[1]/text()",
+		"//div/text",
+		"//div/text/b",
+		"//div/b/text",
+	}
+
+	got := s.GetValueBatch(paths)
+	for i, path := range paths {
+		want, wantErr := s.GetValue(path)
+		if got[i].Value != want || (got[i].Err != nil) != (wantErr != nil) {
+			t.Errorf("GetValueBatch() for %s got = %+v, want value %q and error %v", path, got[i], want, wantErr)
+		}
+	}
+}
+
+func BenchmarkScraperGetValueBatch(b *testing.B) {
+	s, _ := New("https://someAddress", &httpClientWithoutError{})
+	const prefix = "/html/body/div[1]/div[1]/div[1]/div[2]/div[3]/div/div[2]/div/div[1]/div[1]/div/span[1]/span/span/span/span/span/span/span/span/span/span/span/span"
+	paths := []string{prefix + "/text", prefix + "/text[2]", prefix + "/b", prefix + "/text"}
+
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = s.GetValueBatch(paths)
+		}
+	})
+	b.Run("one by one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, path := range paths {
+				_, _ = s.GetValue(path)
+			}
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package scraper
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// domBytesPerBodyByte is a rough ratio of memory taken by a parsed DOM to the size of the HTML it was parsed from
+	domBytesPerBodyByte = 4
+	// unknownBodySize is assumed for responses without Content-Length
+	unknownBodySize = 1 << 20
+)
+
+type (
+	// ParseBudget limits the number of concurrent parses and the estimated memory of DOMs being built.
+	// Parses exceeding the budget wait in a queue. One budget is meant to be shared by all scrapers of a worker.
+	// A document estimated to be larger than the whole memory budget is parsed alone.
+	ParseBudget struct {
+		maxParses int
+		maxBytes  int64
+
+		mu     sync.Mutex
+		cond   *sync.Cond
+		parses int
+		bytes  int64
+		stats  ParseBudgetStats
+	}
+
+	// ParseBudgetStats is a snapshot of ParseBudget usage and wait metrics
+	ParseBudgetStats struct {
+		Parses    int
+		Bytes     int64
+		Waits     uint64
+		TotalWait time.Duration
+		MaxWait   time.Duration
+	}
+)
+
+// NewParseBudget creates a budget of maxParses concurrent parses and maxBytes of estimated DOM memory
+func NewParseBudget(maxParses int, maxBytes int64) (*ParseBudget, error) {
+	if maxParses <= 0 {
+		return nil, errors.New("maxParses should be positive")
+	}
+	if maxBytes <= 0 {
+		return nil, errors.New("maxBytes should be positive")
+	}
+
+	b := &ParseBudget{maxParses: maxParses, maxBytes: maxBytes}
+	b.cond = sync.NewCond(&b.mu)
+
+	return b, nil
+}
+
+// Stats returns current usage and accumulated wait metrics
+func (b *ParseBudget) Stats() ParseBudgetStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := b.stats
+	stats.Parses, stats.Bytes = b.parses, b.bytes
+
+	return stats
+}
+
+// acquire blocks until a parse of size estimated bytes fits the budget and returns a func releasing it
+func (b *ParseBudget) acquire(size int64) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.fits(size) {
+		start := time.Now()
+		for !b.fits(size) {
+			b.cond.Wait()
+		}
+		wait := time.Since(start)
+		b.stats.Waits++
+		b.stats.TotalWait += wait
+		b.stats.MaxWait = max(b.stats.MaxWait, wait)
+	}
+	b.parses++
+	b.bytes += size
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			b.parses--
+			b.bytes -= size
+			b.mu.Unlock()
+			b.cond.Broadcast()
+		})
+	}
+}
+
+func (b *ParseBudget) fits(size int64) bool {
+	if b.parses >= b.maxParses {
+		return false
+	}
+	return b.parses == 0 || b.bytes+size <= b.maxBytes
+}
+
+// estimateDOMSize estimates memory a DOM parsed from a body of contentLength bytes takes
+func estimateDOMSize(contentLength int64) int64 {
+	size := contentLength
+	if size <= 0 {
+		size = unknownBodySize
+	}
+
+	return size * domBytesPerBodyByte
+}
@@ -0,0 +1,72 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBudget(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxParses int
+		maxBytes  int64
+		first     int64
+		second    int64
+		wantWait  bool
+	}{
+		{name: "fits", maxParses: 2, maxBytes: 100, first: 40, second: 60},
+		{name: "too many parses", maxParses: 1, maxBytes: 100, first: 10, second: 10, wantWait: true},
+		{name: "too many bytes", maxParses: 2, maxBytes: 100, first: 60, second: 60, wantWait: true},
+		{name: "oversized alone", maxParses: 2, maxBytes: 100, first: 500, second: 10, wantWait: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NewParseBudget(tt.maxParses, tt.maxBytes)
+			if err != nil {
+				t.Fatalf("NewParseBudget() error = %v", err)
+			}
+
+			release := b.acquire(tt.first)
+			acquired := make(chan struct{})
+			go func() {
+				b.acquire(tt.second)()
+				close(acquired)
+			}()
+
+			select {
+			case <-acquired:
+				if tt.wantWait {
+					t.Fatalf("second parse did not wait for the budget")
+				}
+			case <-time.After(50 * time.Millisecond):
+				if !tt.wantWait {
+					t.Fatalf("second parse waits although it fits the budget")
+				}
+				release()
+				<-acquired
+			}
+			release()
+
+			stats := b.Stats()
+			if stats.Parses != 0 || stats.Bytes != 0 {
+				t.Errorf("Stats() budget is not released: %+v", stats)
+			}
+			if (stats.Waits == 1) != tt.wantWait || (stats.MaxWait > 0) != tt.wantWait {
+				t.Errorf("Stats() got = %+v, wantWait %v", stats, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestNewWithParseBudget(t *testing.T) {
+	b, _ := NewParseBudget(1, 1)
+	if _, err := New("https://someAddress", &httpClientWithoutError{}, WithParseBudget(b)); err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if stats := b.Stats(); stats.Parses != 0 {
+		t.Errorf("New() did not release the budget: %+v", stats)
+	}
+	if _, err := New("https://someAddress", &httpClientWithoutError{}, WithParseBudget(nil)); err == nil {
+		t.Errorf("New() with nil budget expected error")
+	}
+}
@@ -0,0 +1,79 @@
+package scraper
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+)
+
+type (
+	// ExtractionCache is an LRU cache of GetValue results keyed by the hash of the document body and the path.
+	// It is safe for concurrent use and meant to be shared between scrapers re-processing the same documents.
+	ExtractionCache struct {
+		maxEntries int
+
+		mu      sync.Mutex
+		entries map[extractionKey]*list.Element
+		order   *list.List
+	}
+
+	extractionKey struct {
+		hash string
+		path string
+	}
+
+	extractionEntry struct {
+		key   extractionKey
+		value string
+		err   error
+	}
+)
+
+func NewExtractionCache(maxEntries int) (*ExtractionCache, error) {
+	if maxEntries <= 0 {
+		return nil, errors.New("maxEntries should be positive")
+	}
+
+	return &ExtractionCache{
+		maxEntries: maxEntries,
+		entries:    make(map[extractionKey]*list.Element),
+		order:      list.New(),
+	}, nil
+}
+
+// Len returns the number of cached results
+func (c *ExtractionCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *ExtractionCache) get(key extractionKey) (extractionEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return extractionEntry{}, false
+	}
+	c.order.MoveToFront(e)
+
+	return *e.Value.(*extractionEntry), true
+}
+
+func (c *ExtractionCache) put(key extractionKey, value string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&extractionEntry{key: key, value: value, err: err})
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*extractionEntry).key)
+	}
+}
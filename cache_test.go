@@ -0,0 +1,45 @@
+package scraper
+
+import "testing"
+
+func TestScraperGetValueWithExtractionCache(t *testing.T) {
+	const valuePath = "/html/body/div[1]/div[1]/div[1]/div[2]/div[3]/div/div[2]/div/div[1]/div[1]/div/span[1]/span/span/span/span/span/span/span/span/span/span/span/span/text"
+
+	cache, err := NewExtractionCache(2)
+	if err != nil {
+		t.Fatalf("NewExtractionCache() error = %v", err)
+	}
+
+	first, err := New("https://someAddress", &httpClientWithoutError{}, WithExtractionCache(cache))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	second, _ := New("https://someAddress", &httpClientWithoutError{}, WithExtractionCache(cache))
+	if first.hash == "" || first.hash != second.hash {
+		t.Fatalf("documents with the same body should have the same non-empty hash")
+	}
+
+	if got, _ := first.GetValue(valuePath); got != "12 981 - 14 444" {
+		t.Fatalf("GetValue() got = %v", got)
+	}
+	if _, err = first.GetValue("/html/body/table"); err == nil {
+		t.Fatalf("GetValue() expected error")
+	}
+	if cache.Len() != 2 {
+		t.Fatalf("cache has %d entries, want %d", cache.Len(), 2)
+	}
+
+	// the second scraper must be answered from the cache, so the document is not needed
+	second.doc = nil
+	if got, _ := second.GetValue(valuePath); got != "12 981 - 14 444" {
+		t.Errorf("GetValue() from cache got = %v", got)
+	}
+	if _, err = second.GetValue("/html/body/table"); err == nil {
+		t.Errorf("GetValue() from cache expected cached error")
+	}
+
+	_, _ = first.GetValue("/html")
+	if cache.Len() != 2 {
+		t.Errorf("cache has %d entries, want at most %d", cache.Len(), 2)
+	}
+}
@@ -0,0 +1,39 @@
+package scraper
+
+// WithCaseInsensitiveMatching makes paths match tag names and compare strings in predicates ignoring case,
+// so //DIV[@class="Price"] matches <div class="price">, for legacy sites emitting mixed case markup.
+// Attribute names are always matched ignoring case.
+func WithCaseInsensitiveMatching() Option {
+	return func(o *options) error {
+		o.caseInsensitive = true
+		return nil
+	}
+}
+
+// parsePaths parses a union of paths evaluated by the scraper
+func (s *Scraper) parsePaths(fullXPath string) ([][]PathStep, error) {
+	paths, err := parseUnion(fullXPath)
+	if err != nil {
+		return nil, err
+	}
+	if s.caseInsensitive {
+		paths = foldCase(paths)
+	}
+
+	return paths, nil
+}
+
+// foldCase returns copies of paths matching ignoring case, paths themselves are left intact
+// as compiled paths are shared between scrapers
+func foldCase(paths [][]PathStep) [][]PathStep {
+	folded := make([][]PathStep, len(paths))
+	for i, path := range paths {
+		folded[i] = make([]PathStep, len(path))
+		for j, step := range path {
+			step.fold = true
+			folded[i][j] = step
+		}
+	}
+
+	return folded
+}
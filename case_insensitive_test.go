@@ -0,0 +1,95 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithCaseInsensitiveMatching(t *testing.T) {
+	page := `<HTML><BODY>
+<DIV CLASS="Price">10</DIV>
+<div class="PRICE-old">12</div>
+<div class="other">13</div>
+</BODY></HTML>`
+	client := httpClientWithPages{"https://legacy.test/": page}
+
+	sensitive, err := New("https://legacy.test/", client)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	insensitive, err := New("https://legacy.test/", client, WithCaseInsensitiveMatching())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		wantSensitive []string
+		want          []string
+	}{
+		{
+			name:          "tag names",
+			path:          "/HTML/Body/DIV[1]/text()",
+			wantSensitive: []string{},
+			want:          []string{"10"},
+		},
+		{
+			name:          "attribute comparison",
+			path:          `//div[@CLASS="price"]/text()`,
+			wantSensitive: []string{},
+			want:          []string{"10"},
+		},
+		{
+			name:          "string functions",
+			path:          `//div[starts-with(@class, "price")]/text()`,
+			wantSensitive: []string{},
+			want:          []string{"10", "12"},
+		},
+		{
+			name:          "not equals",
+			path:          `//div[@class!="PRICE"]/text()`,
+			wantSensitive: []string{"10", "12", "13"},
+			want:          []string{"12", "13"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sensitive.GetValues(tt.path)
+			if err != nil {
+				t.Fatalf("GetValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantSensitive) {
+				t.Errorf("GetValues() without the option got = %q, want %q", got, tt.wantSensitive)
+			}
+
+			got, err = insensitive.GetValues(tt.path)
+			if err != nil {
+				t.Fatalf("GetValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %q, want %q", got, tt.want)
+			}
+
+			p, err := CompilePath(tt.path)
+			if err != nil {
+				t.Fatalf("CompilePath() error = %v", err)
+			}
+			node, err := insensitive.FindCompiled(p)
+			if err != nil || node.Data != tt.want[0] {
+				t.Errorf("FindCompiled() got = %v, error = %v", node, err)
+			}
+			if batch := insensitive.GetValueBatch([]string{tt.path}); batch[0].Value != tt.want[0] {
+				t.Errorf("GetValueBatch() got = %+v", batch[0])
+			}
+		})
+	}
+
+	body, err := insensitive.FindElement("/HTML/BODY")
+	if err != nil {
+		t.Fatalf("FindElement() error = %v", err)
+	}
+	if got, _ := body.GetValue(`DIV[@class="price-OLD"]/text()`); got != "12" {
+		t.Errorf("GetValue() of element got = %q, want %q", got, "12")
+	}
+}
@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// ClientOption configures the client created by NewHTTPClientWithRetry
+type ClientOption func(*httpClientWithRetry) error
+
+// WithRetryPolicy sets the policy deciding which failed attempts are retried, DefaultRetryPolicy is used by default
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if policy == nil {
+			return errors.New("retry policy should be not nil")
+		}
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithAddressFamily sets which IP address family connections are made over, AddressFamilyAny by default
+func WithAddressFamily(family AddressFamily) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if family < AddressFamilyAny || family > AddressFamilyIPv6Only {
+			return fmt.Errorf("unknown address family: %d", family)
+		}
+		c.dialer.family = family
+		return nil
+	}
+}
+
+// WithFallbackDelay sets how long the dialer waits before racing a connection over the other address family
+// when AddressFamilyAny is used (Happy Eyeballs). Zero means the default of 300ms, negative disables the fallback.
+func WithFallbackDelay(delay time.Duration) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		c.dialer.dialer.FallbackDelay = delay
+		return nil
+	}
+}
+
+// WithDNSCache makes the client resolve hosts through cache, which may be shared between clients.
+// Addresses of a host are dialed one by one, so Happy Eyeballs racing does not apply.
+func WithDNSCache(cache *DNSCache) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if cache == nil {
+			return errors.New("dns cache should be not nil")
+		}
+		c.dialer.cache = cache
+		return nil
+	}
+}
+
+// WithPooledConnections makes the client keep idle connections and reuse them for subsequent requests to the same host.
+// The default client disables keep-alives, which is fine for one-off requests but slow when crawling a single site.
+func WithPooledConnections() ClientOption {
+	return func(c *httpClientWithRetry) error {
+		c.client = cleanhttp.DefaultPooledClient()
+		return nil
+	}
+}
+
+// WithRetryRecorder enables debug mode: every attempt with its trigger, backoff, proxy and headers is added to recorder
+func WithRetryRecorder(recorder *RetryRecorder) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if recorder == nil {
+			return errors.New("retry recorder should be not nil")
+		}
+		c.recorder = recorder
+		return nil
+	}
+}
+
+// WithCookies makes the client send cookies with every request, for example to pin the variant of an A/B test
+// assigned by a cookie once DetectVariants found more than one
+func WithCookies(cookies ...*http.Cookie) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		for _, cookie := range cookies {
+			if cookie == nil || cookie.Name == "" {
+				return errors.New("cookie should have a name")
+			}
+			c.cookies = append(c.cookies, cookie)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package scraper
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestWithPooledConnections(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []ClientOption
+		wantKeepAlive bool
+	}{
+		{name: "default", wantKeepAlive: false},
+		{name: "pooled", opts: []ClientOption{WithPooledConnections()}, wantKeepAlive: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewHTTPClientWithRetry(0, 0, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+			}
+			transport := client.(*httpClientWithRetry).client.Transport.(*http.Transport)
+			if transport.DisableKeepAlives == tt.wantKeepAlive {
+				t.Errorf("DisableKeepAlives = %v, want %v", transport.DisableKeepAlives, !tt.wantKeepAlive)
+			}
+			if transport.DialContext == nil {
+				t.Errorf("DialContext is not installed")
+			}
+		})
+	}
+}
+
+func TestWithCookies(t *testing.T) {
+	var gotVariant string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("ab_variant"); err == nil {
+			gotVariant = cookie.Value
+		}
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+
+	client, err := NewHTTPClientWithRetry(0, 0, WithCookies(&http.Cookie{Name: "ab_variant", Value: "B"}))
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+	resp, err := client.Get(target)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if gotVariant != "B" {
+		t.Errorf("variant cookie got = %v, want %v", gotVariant, "B")
+	}
+
+	if _, err = NewHTTPClientWithRetry(0, 0, WithCookies(&http.Cookie{Value: "B"})); err == nil {
+		t.Errorf("NewHTTPClientWithRetry() expected error for cookie without name")
+	}
+}
+
+func BenchmarkHTTPClientWithRetryGet(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("<html><body>ok</body></html>"))
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+
+	profiles := []struct {
+		name string
+		opts []ClientOption
+	}{
+		{name: "default"},
+		{name: "pooled", opts: []ClientOption{WithPooledConnections()}},
+	}
+	for _, p := range profiles {
+		b.Run(p.name, func(b *testing.B) {
+			client, err := NewHTTPClientWithRetry(0, 0, p.opts...)
+			if err != nil {
+				b.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				resp, err := client.Get(target)
+				if err != nil {
+					b.Fatalf("Get() error = %v", err)
+				}
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+			}
+		})
+	}
+}
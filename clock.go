@@ -0,0 +1,86 @@
+package scraper
+
+import (
+	"errors"
+	"time"
+)
+
+type (
+	// Clock tells the current time. A fixed clock makes timestamps of audit records and retry attempts
+	// reproducible, so runs against identical snapshots produce byte-identical outputs. Such a deterministic run
+	// has to replace every source of time and randomness it uses:
+	//   - WithClock and WithSleeper of the retry client and WithAuditClock of the audit client
+	//   - generate of WithRequestID and nonce of NewHMACSigner, both random if nil
+	//   - clock of NewECBRates, NewPreviewer, NewBearerAuth, NewOAuth2ClientCredentials and NewDNSCache
+	//
+	// Durations measured WithTimings are wall clock durations and vary between runs.
+	Clock interface {
+		Now() time.Time
+	}
+
+	// ClockFunc is a Clock calling the function
+	ClockFunc func() time.Time
+
+	// Sleeper waits between attempts of the retry client. A sleeper returning immediately and recording
+	// the durations lets tests check backoff without waiting for it.
+	Sleeper interface {
+		Sleep(d time.Duration)
+	}
+
+	// SleeperFunc is a Sleeper calling the function
+	SleeperFunc func(d time.Duration)
+)
+
+var (
+	// SystemClock is the wall clock used by default
+	SystemClock Clock = ClockFunc(time.Now)
+	// SystemSleeper pauses the goroutine with time.Sleep and is used by default
+	SystemSleeper Sleeper = SleeperFunc(time.Sleep)
+)
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+func (f SleeperFunc) Sleep(d time.Duration) {
+	f(d)
+}
+
+// FixedClock returns a Clock always telling t
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// WithClock sets the clock stamping attempts recorded by WithRetryRecorder and signatures of WithSigner,
+// SystemClock is used by default
+func WithClock(clock Clock) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if clock == nil {
+			return errors.New("clock should be not nil")
+		}
+		c.clock = clock
+		return nil
+	}
+}
+
+// WithSleeper sets how the client waits before retrying a failed attempt, SystemSleeper is used by default
+func WithSleeper(sleeper Sleeper) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if sleeper == nil {
+			return errors.New("sleeper should be not nil")
+		}
+		c.sleeper = sleeper
+		return nil
+	}
+}
+
+// WithAuditClock sets the clock stamping audit records, SystemClock is used by default
+func WithAuditClock(clock Clock) AuditOption {
+	return func(c *auditHTTPClient) error {
+		if clock == nil {
+			return errors.New("clock should be not nil")
+		}
+		c.clock = clock
+		return nil
+	}
+}
@@ -0,0 +1,97 @@
+package scraper
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAuditHTTPClientWithAuditClock(t *testing.T) {
+	target, _ := url.Parse("https://someAddress")
+	run := func() []byte {
+		var buf bytes.Buffer
+		client, err := NewAuditHTTPClient(&httpClientWithoutError{}, &buf, WithAuditClock(FixedClock(time.Unix(1700000000, 0))))
+		if err != nil {
+			t.Fatalf("NewAuditHTTPClient() error = %v", err)
+		}
+		for i := 0; i < 2; i++ {
+			resp, err := client.Get(target)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		return buf.Bytes()
+	}
+
+	first, second := run(), run()
+	if !bytes.Equal(first, second) {
+		t.Errorf("audit logs of identical runs differ:\n%s\n%s", first, second)
+	}
+	if !bytes.Contains(first, []byte(`"timestamp":"2023-11-14T22:13:20Z"`)) {
+		t.Errorf("audit log is not stamped by the clock: %s", first)
+	}
+
+	if _, err := NewAuditHTTPClient(&httpClientWithoutError{}, io.Discard, WithAuditClock(nil)); err == nil {
+		t.Errorf("NewAuditHTTPClient() with nil clock expected error")
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	recorder := NewRetryRecorder()
+	client, err := NewHTTPClientWithRetry(0, 0, WithRetryRecorder(recorder), WithClock(FixedClock(now)))
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+	client.(*httpClientWithRetry).client = &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})}
+
+	target, _ := url.Parse("https://someAddress")
+	if _, err = client.Get(target); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := recorder.Attempts(); len(got) != 1 || !got[0].Time.Equal(now) {
+		t.Errorf("Attempts() got = %+v, want one attempt at %s", got, now)
+	}
+
+	if _, err = NewHTTPClientWithRetry(0, 0, WithClock(nil)); err == nil {
+		t.Errorf("NewHTTPClientWithRetry() with nil clock expected error")
+	}
+	if _, err = NewHTTPClientWithRetry(0, 0, WithHostOptions("*.example.com", WithClock(SystemClock))); err == nil {
+		t.Errorf("NewHTTPClientWithRetry() with scoped clock expected error")
+	}
+}
+
+func TestWithSleeper(t *testing.T) {
+	var slept []time.Duration
+	client, err := NewHTTPClientWithRetry(2, 30*time.Second, WithSleeper(SleeperFunc(func(d time.Duration) {
+		slept = append(slept, d)
+	})))
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+	client.(*httpClientWithRetry).client = &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	})}
+
+	target, _ := url.Parse("https://someAddress")
+	if _, err = client.Get(target); err == nil {
+		t.Fatalf("Get() expected error")
+	}
+	if want := []time.Duration{30 * time.Second, 30 * time.Second}; !reflect.DeepEqual(slept, want) {
+		t.Errorf("slept = %v, want %v", slept, want)
+	}
+
+	if _, err = NewHTTPClientWithRetry(0, 0, WithSleeper(nil)); err == nil {
+		t.Errorf("NewHTTPClientWithRetry() with nil sleeper expected error")
+	}
+}
@@ -0,0 +1,218 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"golang.org/x/net/html"
+)
+
+// ErrAlreadyVisited is returned by Collector.Visit for URLs visited before
+var ErrAlreadyVisited = errors.New("URL already visited")
+
+type (
+	// Collector is a Colly-like API on top of this package: callbacks registered with OnHTML are called
+	// for every element matching their CSS selector on each visited page. Pages are fetched with the given
+	// HTTPClient and parsed by New with the given options, so retries, caching and budgets apply as usual.
+	Collector struct {
+		client HTTPClient
+		opts   []Option
+
+		// AllowURLRevisit makes Visit fetch URLs visited before instead of returning ErrAlreadyVisited
+		AllowURLRevisit bool
+		// Locales makes Visit also visit hreflang alternates of each page in these locales, like "de" or "en-GB".
+		// Elements of such pages have Locale set to the matched locale.
+		Locales []string
+
+		mu             sync.Mutex
+		htmlCallbacks  []htmlCallback
+		errorCallbacks []ErrorCallback
+		visited        map[string]bool
+	}
+
+	// HTMLElement is an element matched by an OnHTML selector
+	HTMLElement struct {
+		Name string
+		Text string
+		// URL of the page the element belongs to
+		URL  *url.URL
+		DOM  *goquery.Selection
+		Node *html.Node
+		// Index of the element among elements matched by the same callback on the page
+		Index int
+		// Locale the page was visited for as a hreflang alternate, empty for pages visited directly
+		Locale string
+
+		collector *Collector
+	}
+
+	HTMLCallback  func(*HTMLElement)
+	ErrorCallback func(u string, err error)
+
+	htmlCallback struct {
+		selector string
+		matcher  cascadia.Selector
+		fn       HTMLCallback
+	}
+)
+
+func NewCollector(client HTTPClient, opts ...Option) (*Collector, error) {
+	if client == nil {
+		return nil, errors.New("client should be not nil")
+	}
+
+	return &Collector{
+		client:  client,
+		opts:    opts,
+		visited: make(map[string]bool),
+	}, nil
+}
+
+// OnHTML registers fn to be called for every element matching the CSS selector
+func (c *Collector) OnHTML(selector string, fn HTMLCallback) error {
+	if fn == nil {
+		return errors.New("callback should be not nil")
+	}
+	matcher, err := cascadia.Compile(selector)
+	if err != nil {
+		return fmt.Errorf("compile selector [%s]: %w", selector, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.htmlCallbacks = append(c.htmlCallbacks, htmlCallback{selector: selector, matcher: matcher, fn: fn})
+
+	return nil
+}
+
+// OnError registers fn to be called when a page can't be fetched or parsed
+func (c *Collector) OnError(fn ErrorCallback) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errorCallbacks = append(c.errorCallbacks, fn)
+}
+
+// Visit fetches the page and calls matching callbacks in the order they were registered. If Locales are set,
+// alternates of the page in these locales are visited next, failures are passed to OnError callbacks.
+func (c *Collector) Visit(u string) error {
+	return c.visit(u, "")
+}
+
+func (c *Collector) visit(u, locale string) error {
+	c.mu.Lock()
+	if c.visited[u] && !c.AllowURLRevisit {
+		c.mu.Unlock()
+		return ErrAlreadyVisited
+	}
+	c.visited[u] = true
+	callbacks := make([]htmlCallback, len(c.htmlCallbacks))
+	copy(callbacks, c.htmlCallbacks)
+	c.mu.Unlock()
+
+	s, err := New(u, c.client, c.opts...)
+	if err != nil {
+		c.handleError(u, err)
+		return err
+	}
+
+	doc := s.Document()
+	for _, cb := range callbacks {
+		doc.FindMatcher(cb.matcher).Each(func(i int, sel *goquery.Selection) {
+			cb.fn(c.newHTMLElement(s.URL(), locale, sel, i))
+		})
+	}
+
+	if locale == "" && len(c.Locales) != 0 {
+		for _, alternate := range s.Alternates() {
+			if l, ok := matchLocale(alternate.Lang, c.Locales); ok {
+				// errors are already passed to OnError callbacks
+				_ = c.visit(alternate.Href, l)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) handleError(u string, err error) {
+	c.mu.Lock()
+	callbacks := make([]ErrorCallback, len(c.errorCallbacks))
+	copy(callbacks, c.errorCallbacks)
+	c.mu.Unlock()
+
+	for _, fn := range callbacks {
+		fn(u, err)
+	}
+}
+
+func (c *Collector) newHTMLElement(u *url.URL, locale string, sel *goquery.Selection, index int) *HTMLElement {
+	return &HTMLElement{
+		Name:      goquery.NodeName(sel),
+		Text:      sel.Text(),
+		URL:       u,
+		DOM:       sel,
+		Node:      sel.Nodes[0],
+		Index:     index,
+		Locale:    locale,
+		collector: c,
+	}
+}
+
+// Attr returns the value of the attribute of the element, empty if it is absent
+func (e *HTMLElement) Attr(key string) string {
+	v, _ := attr(e.Node, key)
+	return v
+}
+
+// ChildText returns the concatenated and trimmed text of descendants matching the selector
+func (e *HTMLElement) ChildText(selector string) string {
+	return strings.TrimSpace(e.DOM.Find(selector).Text())
+}
+
+// ChildAttr returns the attribute of the first descendant matching the selector
+func (e *HTMLElement) ChildAttr(selector, key string) string {
+	v, _ := e.DOM.Find(selector).Attr(key)
+	return strings.TrimSpace(v)
+}
+
+// ChildAttrs returns the attribute of all descendants matching the selector
+func (e *HTMLElement) ChildAttrs(selector, key string) []string {
+	var values []string
+	e.DOM.Find(selector).Each(func(_ int, sel *goquery.Selection) {
+		if v, ok := sel.Attr(key); ok {
+			values = append(values, strings.TrimSpace(v))
+		}
+	})
+
+	return values
+}
+
+// ForEach calls fn for every descendant matching the selector
+func (e *HTMLElement) ForEach(selector string, fn func(int, *HTMLElement)) {
+	e.DOM.Find(selector).Each(func(i int, sel *goquery.Selection) {
+		fn(i, e.collector.newHTMLElement(e.URL, e.Locale, sel, i))
+	})
+}
+
+// AbsoluteURL resolves u relative to the page of the element, empty if u can't be parsed
+func (e *HTMLElement) AbsoluteURL(u string) string {
+	ref, err := url.Parse(strings.TrimSpace(u))
+	if err != nil {
+		return ""
+	}
+	if e.URL == nil {
+		return ref.String()
+	}
+
+	return e.URL.ResolveReference(ref).String()
+}
+
+// Visit visits u resolved relative to the page of the element with the same collector
+func (e *HTMLElement) Visit(u string) error {
+	return e.collector.Visit(e.AbsoluteURL(u))
+}
@@ -0,0 +1,92 @@
+package scraper
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// httpClientWithPages serves pages by URL and responds with 404 for unknown URLs
+type httpClientWithPages map[string]string
+
+func (c httpClientWithPages) Get(u *url.URL) (*http.Response, error) {
+	page, ok := c[u.String()]
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(page)),
+		Request:    &http.Request{URL: u},
+	}, nil
+}
+
+func TestCollectorVisit(t *testing.T) {
+	client := httpClientWithPages{
+		"https://shop.test/":       `<ul><li><a href="/item/1">first</a></li><li><a href="item/2"> second </a></li><li><a href="/missing">gone</a></li></ul>`,
+		"https://shop.test/item/1": `<h1 class="name">One</h1><span class="price">10 USD</span><a href="/">home</a>`,
+		"https://shop.test/item/2": `<h1 class="name">Two</h1><span class="price">20 USD</span>`,
+	}
+
+	c, err := NewCollector(client)
+	if err != nil {
+		t.Fatalf("NewCollector() error = %v", err)
+	}
+
+	var links, names []string
+	var failed []string
+	if err = c.OnHTML("li > a[href]", func(e *HTMLElement) {
+		links = append(links, e.AbsoluteURL(e.Attr("href")))
+		_ = e.Visit(e.Attr("href"))
+	}); err != nil {
+		t.Fatalf("OnHTML() error = %v", err)
+	}
+	if err = c.OnHTML("body", func(e *HTMLElement) {
+		if name := e.ChildText("h1.name"); name != "" {
+			names = append(names, name+" "+e.ChildText(".price"))
+		}
+		if href := e.ChildAttr("a", "href"); href != "" {
+			if err := e.Visit(href); !errors.Is(err, ErrAlreadyVisited) {
+				t.Errorf("Visit() of a visited page error = %v, want %v", err, ErrAlreadyVisited)
+			}
+		}
+	}); err != nil {
+		t.Fatalf("OnHTML() error = %v", err)
+	}
+	c.OnError(func(u string, _ error) {
+		failed = append(failed, u)
+	})
+
+	if err = c.Visit("https://shop.test/"); err != nil {
+		t.Fatalf("Visit() error = %v", err)
+	}
+
+	wantLinks := []string{"https://shop.test/item/1", "https://shop.test/item/2", "https://shop.test/missing"}
+	if strings.Join(links, ",") != strings.Join(wantLinks, ",") {
+		t.Errorf("links got = %v, want %v", links, wantLinks)
+	}
+	wantNames := []string{"One 10 USD", "Two 20 USD"}
+	if strings.Join(names, ",") != strings.Join(wantNames, ",") {
+		t.Errorf("names got = %v, want %v", names, wantNames)
+	}
+	if len(failed) != 1 || failed[0] != "https://shop.test/missing" {
+		t.Errorf("OnError() got = %v, want the missing page", failed)
+	}
+}
+
+func TestCollectorOnHTMLInvalidSelector(t *testing.T) {
+	c, err := NewCollector(&httpClientWithoutError{})
+	if err != nil {
+		t.Fatalf("NewCollector() error = %v", err)
+	}
+	if err = c.OnHTML("a[", func(*HTMLElement) {}); err == nil {
+		t.Errorf("OnHTML() expected error for invalid selector")
+	}
+	if _, err = NewCollector(nil); err == nil {
+		t.Errorf("NewCollector() expected error for nil client")
+	}
+}
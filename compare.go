@@ -0,0 +1,141 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type (
+	// CompareItem is the same item offered on several sites
+	CompareItem struct {
+		Name    string
+		Sources []CompareSource
+		// Currency all prices are converted to, the currency of the first found price with a currency if empty.
+		// Prices without currency are compared only if none of the found prices has one.
+		Currency string
+	}
+
+	// CompareSource is a page of a site offering the item.
+	// XPath points to the price text, the Product preset is used if it is empty.
+	CompareSource struct {
+		Site  string
+		URL   string
+		XPath string
+	}
+
+	// CompareResult is the price of the item on one site, Price is converted to the comparison currency
+	CompareResult struct {
+		Source CompareSource
+		Price  Money
+		Err    error
+	}
+
+	// Comparison is a consolidated comparison of the item across sites.
+	// Results are in the order of CompareItem.Sources; Min, Max and Median are computed over successful ones.
+	Comparison struct {
+		Item     string
+		Results  []CompareResult
+		Min      Money
+		Max      Money
+		Median   Money
+		Cheapest string
+	}
+)
+
+// Compare fetches all sources of item in parallel and compares their prices.
+// Prices in different currencies are converted with rates, they fail with an error if rates is nil.
+// An error is returned only if item is invalid or no site returned a price, per-site errors are in the results.
+func Compare(client HTTPClient, item CompareItem, rates RateProvider) (Comparison, error) {
+	if client == nil {
+		return Comparison{}, errors.New("client should be not nil")
+	}
+	if len(item.Sources) == 0 {
+		return Comparison{}, errors.New("item should have at least one source")
+	}
+
+	comparison := Comparison{Item: item.Name, Results: make([]CompareResult, len(item.Sources))}
+
+	errs := fanOut(context.Background(), len(item.Sources), 0, func(_ context.Context, i int) error {
+		price, err := fetchPrice(client, item.Sources[i])
+		comparison.Results[i] = CompareResult{Source: item.Sources[i], Price: price, Err: err}
+		return nil
+	})
+	for i, err := range errs {
+		if err != nil {
+			comparison.Results[i] = CompareResult{Source: item.Sources[i], Err: err}
+		}
+	}
+
+	currency := strings.ToUpper(item.Currency)
+	for i := 0; currency == "" && i < len(comparison.Results); i++ {
+		if comparison.Results[i].Err == nil {
+			currency = comparison.Results[i].Price.Currency
+		}
+	}
+	var found []CompareResult
+	for i := range comparison.Results {
+		r := &comparison.Results[i]
+		if r.Err != nil {
+			continue
+		}
+		if r.Price.Currency != currency {
+			if r.Price.Currency == "" {
+				r.Err = fmt.Errorf("price has no currency to compare with [%s]", currency)
+				continue
+			}
+			if rates == nil {
+				r.Err = fmt.Errorf("price currency [%s] differs from [%s] and no rates are given", r.Price.Currency, currency)
+				continue
+			}
+			converted, err := r.Price.ConvertTo(currency, rates)
+			if err != nil {
+				r.Err = fmt.Errorf("convert price: %w", err)
+				continue
+			}
+			r.Price = converted
+		}
+		found = append(found, *r)
+	}
+	if len(found) == 0 {
+		return comparison, errors.New("no site returned a price")
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Price.Amount < found[j].Price.Amount })
+	comparison.Min = found[0].Price
+	comparison.Max = found[len(found)-1].Price
+	comparison.Cheapest = found[0].Source.Site
+	comparison.Median = Money{Amount: found[len(found)/2].Price.Amount, Currency: currency}
+	if len(found)%2 == 0 {
+		comparison.Median.Amount = (found[len(found)/2-1].Price.Amount + found[len(found)/2].Price.Amount) / 2
+	}
+
+	return comparison, nil
+}
+
+func fetchPrice(client HTTPClient, source CompareSource) (Money, error) {
+	s, err := New(source.URL, client)
+	if err != nil {
+		return Money{}, err
+	}
+
+	if source.XPath == "" {
+		product, err := s.Product()
+		if err != nil {
+			return Money{}, fmt.Errorf("extract product: %w", err)
+		}
+		if product.Price.Amount == 0 {
+			return Money{}, errors.New("product has no price")
+		}
+		return product.Price, nil
+	}
+
+	value, err := s.GetValue(source.XPath)
+	if err != nil {
+		return Money{}, fmt.Errorf("get value: %w", err)
+	}
+
+	return ParsePrice(value)
+}
@@ -0,0 +1,100 @@
+package scraper
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	const priceXPath = "/html/body/div[1]/div[1]/div[1]/div[2]/div[3]/div/div[2]/div/div[1]/div[1]/div/span[1]/span/span/span/span/span/span/span/span/span/span/span/span/text"
+
+	rates, _ := NewFixedRates("UAH", map[string]float64{"USD": 0.025})
+
+	got, err := Compare(&httpClientWithoutError{}, CompareItem{
+		Name: "GTX 1060",
+		Sources: []CompareSource{
+			{Site: "preset", URL: "https://someAddress"},
+			{Site: "xpath", URL: "https://someAddress", XPath: priceXPath},
+			{Site: "broken", URL: "https://someAddress", XPath: "/html/body/table"},
+		},
+		Currency: "USD",
+	}, rates)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	if got.Results[0].Err != nil || got.Results[2].Err == nil {
+		t.Errorf("Compare() unexpected per-site errors: %v, %v", got.Results[0].Err, got.Results[2].Err)
+	}
+	// the xpath value has no currency, so it can't be compared with USD
+	if got.Results[1].Err == nil {
+		t.Errorf("Compare() expected currency error for a price without currency")
+	}
+	want := Money{Amount: 12981 * 0.025, Currency: "USD"}
+	for _, m := range []Money{got.Min, got.Max, got.Median} {
+		if m.Currency != want.Currency || math.Abs(m.Amount-want.Amount) > 1e-9 {
+			t.Errorf("Compare() got = %+v, want min, max and median %v", got, want)
+		}
+	}
+	if got.Cheapest != "preset" {
+		t.Errorf("Compare() Cheapest got = %v, want %v", got.Cheapest, "preset")
+	}
+
+	// without an item currency the first price with a currency sets it, even if a price without one comes first
+	got, err = Compare(&httpClientWithoutError{}, CompareItem{
+		Sources: []CompareSource{
+			{Site: "xpath", URL: "https://someAddress", XPath: priceXPath},
+			{Site: "preset", URL: "https://someAddress"},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if got.Results[0].Err == nil || got.Results[1].Err != nil || got.Min != (Money{Amount: 12981, Currency: "UAH"}) {
+		t.Errorf("Compare() without item currency got = %+v", got)
+	}
+
+	got, err = Compare(&httpClientWithoutError{}, CompareItem{
+		Sources: []CompareSource{
+			{Site: "first", URL: "https://someAddress", XPath: priceXPath},
+			{Site: "second", URL: "https://someAddress", XPath: priceXPath},
+		},
+	}, nil)
+	if err != nil || got.Results[1].Err != nil || got.Median != (Money{Amount: 12981}) {
+		t.Errorf("Compare() of prices without currency got = %+v, error = %v", got, err)
+	}
+
+	if _, err = Compare(&httpClientWithError{}, CompareItem{Sources: []CompareSource{{URL: "https://someAddress"}}}, nil); err == nil {
+		t.Errorf("Compare() expected error when no site returned a price")
+	}
+	if _, err = Compare(&httpClientWithoutError{}, CompareItem{}, nil); err == nil {
+		t.Errorf("Compare() expected error for item without sources")
+	}
+}
+
+type httpClientWithPanic struct{}
+
+func (*httpClientWithPanic) Get(*url.URL) (*http.Response, error) {
+	panic("client bug")
+}
+
+func TestCompareRecoversPanics(t *testing.T) {
+	got, err := Compare(&httpClientWithPanic{}, CompareItem{
+		Name:    "GTX 1060",
+		Sources: []CompareSource{{Site: "panicking", URL: "https://someAddress"}},
+	}, nil)
+	if err == nil {
+		t.Fatalf("Compare() expected error")
+	}
+
+	var panicErr *PanicError
+	if !errors.As(got.Results[0].Err, &panicErr) || panicErr.Value != "client bug" {
+		t.Errorf("Compare() result error = %v, want *PanicError", got.Results[0].Err)
+	}
+	if got.Results[0].Source.Site != "panicking" {
+		t.Errorf("Compare() result source got = %+v", got.Results[0].Source)
+	}
+}
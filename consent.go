@@ -0,0 +1,72 @@
+package scraper
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	// consentOverlayIDs are ids of root elements of common consent managers
+	consentOverlayIDs = map[string]bool{
+		"onetrust-consent-sdk":             true, // OneTrust
+		"onetrust-banner-sdk":              true,
+		"CybotCookiebotDialog":             true, // Cookiebot
+		"CybotCookiebotDialogBodyUnderlay": true,
+		"usercentrics-root":                true, // Usercentrics
+		"didomi-host":                      true, // Didomi
+		"truste-consent-track":             true, // TrustArc
+		"cmpbox":                           true, // consentmanager.net
+		"cmpboxBG":                         true,
+	}
+
+	// consentOverlayClasses are classes of root elements of common consent managers
+	consentOverlayClasses = []string{
+		"qc-cmp2-container", // Quantcast
+		"osano-cm-window",   // Osano
+		"cc-window",         // Cookie Consent by Osano
+	}
+)
+
+// WithoutConsentOverlays removes elements of common consent managers like OneTrust and Cookiebot from the document.
+// Best-effort: banners injected by scripts are not in the static document anyway, and positional paths
+// through the parents of removed elements change.
+func WithoutConsentOverlays() Option {
+	return func(o *options) error {
+		o.removeConsent = true
+		return nil
+	}
+}
+
+// removeConsentOverlays removes consent manager elements under root and returns the number of removed elements
+func removeConsentOverlays(root *html.Node) int {
+	var overlays []*html.Node
+	walk(root, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && isConsentOverlay(n) {
+			overlays = append(overlays, n)
+		}
+		return true
+	})
+
+	for _, n := range overlays {
+		if n.Parent != nil {
+			n.Parent.RemoveChild(n)
+		}
+	}
+
+	return len(overlays)
+}
+
+func isConsentOverlay(n *html.Node) bool {
+	if id, ok := attr(n, "id"); ok && consentOverlayIDs[strings.TrimSpace(id)] {
+		return true
+	}
+	class, _ := attr(n, "class")
+	for _, c := range consentOverlayClasses {
+		if hasToken(class, c) {
+			return true
+		}
+	}
+
+	return false
+}
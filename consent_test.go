@@ -0,0 +1,54 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRemoveConsentOverlays(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<div id="onetrust-consent-sdk"><div id="onetrust-banner-sdk">We use cookies</div></div>
+<div class="page"><p>content</p></div>
+<div id="CybotCookiebotDialog">Cookiebot</div>
+<div class="qc-cmp2-container popup">Quantcast</div>
+<div class="cookie">not a known manager</div>
+</body></html>`)
+
+	if got := removeConsentOverlays(s.doc); got != 4 {
+		t.Errorf("removeConsentOverlays() got = %v, want %v", got, 4)
+	}
+
+	text := textContent(s.doc)
+	for _, removed := range []string{"We use cookies", "Cookiebot", "Quantcast"} {
+		if strings.Contains(text, removed) {
+			t.Errorf("removeConsentOverlays() left [%s]", removed)
+		}
+	}
+	for _, kept := range []string{"content", "not a known manager"} {
+		if !strings.Contains(text, kept) {
+			t.Errorf("removeConsentOverlays() removed [%s]", kept)
+		}
+	}
+}
+
+func TestWithoutConsentOverlays(t *testing.T) {
+	cache, _ := NewExtractionCache(10)
+	page := `<html><body><div id="didomi-host"><p>consent</p></div><div><p>content</p></div></body></html>`
+	client := httpClientWithPages{"https://shop.test/": page}
+
+	s, err := New("https://shop.test/", client, WithExtractionCache(cache))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got, _ := s.GetValue("/html/body/div[1]/p/text"); got != "consent" {
+		t.Errorf("GetValue() got = %v, want %v", got, "consent")
+	}
+
+	s, err = New("https://shop.test/", client, WithExtractionCache(cache), WithoutConsentOverlays())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got, _ := s.GetValue("/html/body/div[1]/p/text"); got != "content" {
+		t.Errorf("GetValue() without overlays got = %v, want %v", got, "content")
+	}
+}
@@ -0,0 +1,27 @@
+package scraper
+
+import (
+	"fmt"
+
+	"github.com/andybalholm/cascadia"
+	"golang.org/x/net/html"
+)
+
+// Select returns all elements matching the CSS selector in document order, empty if there are none.
+// CSS selectors like "div.price > span" usually survive DOM changes which break full XPaths.
+// Elements of a frozen Scraper are detached copies, their parents and siblings can't be reached.
+func (s *Scraper) Select(selector string) ([]*html.Node, error) {
+	matcher, err := cascadia.Compile(selector)
+	if err != nil {
+		return nil, fmt.Errorf("compile selector [%s]: %w", selector, err)
+	}
+
+	nodes := matcher.MatchAll(s.doc)
+	if s.frozen {
+		for i, n := range nodes {
+			nodes[i] = cloneNode(n)
+		}
+	}
+
+	return nodes, nil
+}
@@ -0,0 +1,77 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScraperSelect(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<ul class="products">
+<li data-id="1"><span class="name">One</span><span class="price">10</span></li>
+<li data-id="2"><span class="name">Two</span><span class="price">20</span></li>
+</ul>
+<span class="price">ad</span>
+</body></html>`)
+
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "descendant combinator",
+			selector: "ul.products .price",
+			want:     []string{"10", "20"},
+		},
+		{
+			name:     "attribute and pseudo class",
+			selector: `li[data-id="2"] > span:first-child`,
+			want:     []string{"Two"},
+		},
+		{
+			name:     "group",
+			selector: ".name, body > .price",
+			want:     []string{"One", "Two", "ad"},
+		},
+		{
+			name:     "no match",
+			selector: "table",
+		},
+		{
+			name:     "invalid",
+			selector: "li[",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := s.Select(tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Select() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, n := range nodes {
+				got = append(got, textContent(n))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Select() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraperSelectFrozen(t *testing.T) {
+	s := newTestScraper(t, `<p>text</p>`).Freeze()
+
+	nodes, err := s.Select("p")
+	if err != nil || len(nodes) != 1 {
+		t.Fatalf("Select() got = %v, %v", nodes, err)
+	}
+	nodes[0].FirstChild.Data = "changed"
+
+	if got, _ := s.GetValue("/html/body/p/text"); got != "text" {
+		t.Errorf("GetValue() got = %q after changing a selected node of a frozen scraper", got)
+	}
+}
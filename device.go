@@ -0,0 +1,79 @@
+package scraper
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DeviceProfile makes the client fetch the variant of pages served to a kind of device.
+// Mobile variants often have simpler markup.
+type DeviceProfile struct {
+	Name      string
+	UserAgent string
+	// Header holds device hints like Sec-CH-UA-Mobile and Viewport-Width
+	Header http.Header
+	// HostRewrites maps hosts to hosts of the variant, like "example.com" to "m.example.com"
+	HostRewrites map[string]string
+}
+
+var (
+	MobileDevice = DeviceProfile{
+		Name:      "mobile",
+		UserAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36",
+		Header: http.Header{
+			"Sec-Ch-Ua-Mobile":   {"?1"},
+			"Sec-Ch-Ua-Platform": {`"Android"`},
+			"Viewport-Width":     {"412"},
+		},
+	}
+
+	DesktopDevice = DeviceProfile{
+		Name:      "desktop",
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+		Header: http.Header{
+			"Sec-Ch-Ua-Mobile":   {"?0"},
+			"Sec-Ch-Ua-Platform": {`"Windows"`},
+			"Viewport-Width":     {"1920"},
+		},
+	}
+)
+
+// WithDeviceProfile makes the client send the user agent and hints of profile and rewrite hosts of requested URLs
+func WithDeviceProfile(profile DeviceProfile) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if strings.TrimSpace(profile.UserAgent) == "" {
+			return errors.New("device profile user agent should be not empty")
+		}
+		c.setHeader("User-Agent", profile.UserAgent)
+		for key, values := range profile.Header {
+			for _, v := range values {
+				c.header.Add(key, v)
+			}
+		}
+		if len(profile.HostRewrites) != 0 {
+			c.hostRewrites = make(map[string]string, len(profile.HostRewrites))
+			for from, to := range profile.HostRewrites {
+				c.hostRewrites[strings.ToLower(from)] = to
+			}
+		}
+		return nil
+	}
+}
+
+// rewriteHost returns u with the host replaced according to host rewrites of the client, u itself if none applies
+func (c *httpClientWithRetry) rewriteHost(u *url.URL) *url.URL {
+	to, ok := c.hostRewrites[strings.ToLower(u.Hostname())]
+	if !ok {
+		return u
+	}
+
+	rewritten := *u
+	rewritten.Host = to
+	if port := u.Port(); port != "" {
+		rewritten.Host += ":" + port
+	}
+
+	return &rewritten
+}
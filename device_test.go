@@ -0,0 +1,66 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestWithDeviceProfile(t *testing.T) {
+	var gotHost, gotUserAgent, gotMobile string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost, gotUserAgent, gotMobile = r.Host, r.UserAgent(), r.Header.Get("Sec-CH-UA-Mobile")
+		_, _ = w.Write([]byte("<html><body><p>ok</p></body></html>"))
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	profile := MobileDevice
+	profile.HostRewrites = map[string]string{"Desktop.Test": serverURL.Hostname()}
+
+	client, err := NewHTTPClientWithRetry(0, 0, WithDeviceProfile(profile))
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+	if _, err = New("http://desktop.test:"+serverURL.Port()+"/page", client); err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if gotHost != serverURL.Host {
+		t.Errorf("Host got = %v, want %v", gotHost, serverURL.Host)
+	}
+	if !strings.Contains(gotUserAgent, "Mobile") || gotMobile != "?1" {
+		t.Errorf("device headers got = %v, %v", gotUserAgent, gotMobile)
+	}
+
+	if _, err = NewHTTPClientWithRetry(0, 0, WithDeviceProfile(DeviceProfile{Name: "empty"})); err == nil {
+		t.Errorf("NewHTTPClientWithRetry() expected error for profile without user agent")
+	}
+}
+
+func TestRewriteHost(t *testing.T) {
+	c := &httpClientWithRetry{hostRewrites: map[string]string{"example.com": "m.example.com"}}
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{name: "rewritten", url: "https://example.com/a?b=c", want: "https://m.example.com/a?b=c"},
+		{name: "port kept", url: "http://EXAMPLE.com:8080/", want: "http://m.example.com:8080/"},
+		{name: "other host", url: "https://www.example.com/", want: "https://www.example.com/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, _ := url.Parse(tt.url)
+			if got := c.rewriteHost(u).String(); got != tt.want {
+				t.Errorf("rewriteHost() got = %v, want %v", got, tt.want)
+			}
+			if u.String() != tt.url {
+				t.Errorf("rewriteHost() modified the requested URL")
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package scraper
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	voidElements = map[string]bool{
+		"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true, "input": true,
+		"keygen": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+	}
+
+	// optionalEndTagElements may be left open in valid documents
+	optionalEndTagElements = map[string]bool{
+		"html": true, "head": true, "body": true, "p": true, "li": true, "dt": true, "dd": true, "option": true,
+		"optgroup": true, "tr": true, "td": true, "th": true, "thead": true, "tbody": true, "tfoot": true,
+		"colgroup": true, "rb": true, "rt": true, "rtc": true, "rp": true,
+	}
+)
+
+// ParseDiagnostics describes corrections the parser had to apply to the source.
+// HTML parsing never fails, so a truncated response is "parsed" as well, these numbers help to tell them apart.
+type ParseDiagnostics struct {
+	// ImplicitElements is the number of elements in the document without a start tag in the source, like html, head,
+	// body or tbody inserted by the parser
+	ImplicitElements int
+	// ImplicitlyClosed is the number of elements closed by an end tag of their ancestor
+	ImplicitlyClosed int
+	// StrayEndTags is the number of end tags without a matching open element
+	StrayEndTags int
+	// UnclosedElements is the number of elements left open at the end of the source, except elements
+	// whose end tag is optional
+	UnclosedElements int
+	// UnexpectedEOF reports whether the source ended inside a tag, a comment or a script like element
+	UnexpectedEOF bool
+}
+
+// ProbablyTruncated reports whether the source looks cut off rather than a complete document
+func (d ParseDiagnostics) ProbablyTruncated() bool {
+	return d.UnexpectedEOF || d.UnclosedElements != 0
+}
+
+// WithParseDiagnostics makes the Scraper collect ParseDiagnostics, which requires keeping a copy of the body
+// during parsing
+func WithParseDiagnostics() Option {
+	return func(o *options) error {
+		o.diagnostics = true
+		return nil
+	}
+}
+
+// Diagnostics returns diagnostics of parsing, false if they were not collected
+func (s *Scraper) Diagnostics() (ParseDiagnostics, bool) {
+	if s.diagnostics == nil {
+		return ParseDiagnostics{}, false
+	}
+	return *s.diagnostics, true
+}
+
+// diagnose compares tags of the source with elements of the parsed document
+func diagnose(source []byte, doc *html.Node) ParseDiagnostics {
+	var (
+		d         ParseDiagnostics
+		open      []string
+		startTags = make(map[string]int)
+	)
+
+	z := html.NewTokenizer(bytes.NewReader(source))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			d.UnexpectedEOF = d.UnexpectedEOF || len(z.Raw()) != 0
+			break
+		}
+
+		switch tt {
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			startTags[string(name)]++
+			if !voidElements[string(name)] {
+				open = append(open, string(name))
+			}
+		case html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			startTags[string(name)]++
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			i := len(open) - 1
+			for ; i >= 0 && open[i] != string(name); i-- {
+			}
+			if i < 0 {
+				d.StrayEndTags++
+				continue
+			}
+			for _, tag := range open[i+1:] {
+				if !optionalEndTagElements[tag] {
+					d.ImplicitlyClosed++
+				}
+			}
+			open = open[:i]
+		case html.CommentToken:
+			raw := z.Raw()
+			if bytes.HasPrefix(raw, []byte("<!--")) && !bytes.HasSuffix(raw, []byte("-->")) {
+				d.UnexpectedEOF = true
+			}
+		}
+	}
+
+	for _, tag := range open {
+		if !optionalEndTagElements[tag] {
+			d.UnclosedElements++
+		}
+	}
+	if len(open) != 0 {
+		switch open[len(open)-1] {
+		case "script", "style", "textarea", "title", "xmp", "iframe", "noembed", "noframes", "plaintext":
+			d.UnexpectedEOF = true
+		}
+	}
+
+	elementsCount := make(map[string]int)
+	walk(doc, func(n *html.Node) bool {
+		if n.Type == html.ElementNode {
+			elementsCount[strings.ToLower(n.Data)]++
+		}
+		return true
+	})
+	for tag, n := range elementsCount {
+		if n > startTags[tag] {
+			d.ImplicitElements += n - startTags[tag]
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,94 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name          string
+		source        string
+		want          ParseDiagnostics
+		wantTruncated bool
+	}{
+		{
+			name:   "complete document",
+			source: "<!DOCTYPE html><html><head><title>t</title></head><body><p>a<br><img src=x></p></body></html>",
+			want:   ParseDiagnostics{},
+		},
+		{
+			name:   "implicit elements",
+			source: "<table><tr><td>a</td></tr></table>",
+			want:   ParseDiagnostics{ImplicitElements: 4},
+		},
+		{
+			name:   "implicitly closed and stray end tags",
+			source: "<html><head></head><body><div><span>a</div></em></body></html>",
+			want:   ParseDiagnostics{ImplicitlyClosed: 1, StrayEndTags: 1},
+		},
+		{
+			name:          "cut inside a tag",
+			source:        "<html><head></head><body><div>a</div><div cla",
+			want:          ParseDiagnostics{UnexpectedEOF: true},
+			wantTruncated: true,
+		},
+		{
+			name:          "cut inside a script",
+			source:        "<html><head><script>var a = 1",
+			want:          ParseDiagnostics{ImplicitElements: 1, UnclosedElements: 1, UnexpectedEOF: true},
+			wantTruncated: true,
+		},
+		{
+			name:          "cut inside a comment",
+			source:        "<html><head></head><body><!-- comm",
+			want:          ParseDiagnostics{UnexpectedEOF: true},
+			wantTruncated: true,
+		},
+		{
+			name:          "unclosed elements",
+			source:        "<html><head></head><body><div><section>a",
+			want:          ParseDiagnostics{UnclosedElements: 2},
+			wantTruncated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(tt.source))
+			if err != nil {
+				t.Fatalf("html.Parse() error = %v", err)
+			}
+			got := diagnose([]byte(tt.source), doc)
+			if got != tt.want {
+				t.Errorf("diagnose() got = %+v, want %+v", got, tt.want)
+			}
+			if got.ProbablyTruncated() != tt.wantTruncated {
+				t.Errorf("ProbablyTruncated() got = %v, want %v", got.ProbablyTruncated(), tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestScraperDiagnostics(t *testing.T) {
+	s, err := New("https://someAddress", &httpClientWithoutError{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, ok := s.Diagnostics(); ok {
+		t.Errorf("Diagnostics() collected without WithParseDiagnostics")
+	}
+
+	s, err = New("https://someAddress", &httpClientWithoutError{}, WithParseDiagnostics())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	d, ok := s.Diagnostics()
+	if !ok {
+		t.Fatalf("Diagnostics() not collected")
+	}
+	if d.UnexpectedEOF {
+		t.Errorf("Diagnostics() got = %+v, want a complete document", d)
+	}
+}
@@ -0,0 +1,82 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// AddressFamily selects which IP address family the client connects over
+type AddressFamily int
+
+const (
+	// AddressFamilyAny lets the dialer pick addresses in resolver order with Happy Eyeballs fallback
+	AddressFamilyAny AddressFamily = iota
+	// AddressFamilyPreferIPv4 dials over IPv4 first and falls back to IPv6 if it fails
+	AddressFamilyPreferIPv4
+	// AddressFamilyPreferIPv6 dials over IPv6 first and falls back to IPv4 if it fails
+	AddressFamilyPreferIPv6
+	AddressFamilyIPv4Only
+	AddressFamilyIPv6Only
+)
+
+const (
+	dialTimeout   = 30 * time.Second
+	dialKeepAlive = 30 * time.Second
+)
+
+type dialer struct {
+	dialer *net.Dialer
+	family AddressFamily
+	cache  *DNSCache
+}
+
+func newDialer() *dialer {
+	return &dialer{
+		dialer: &net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: dialKeepAlive,
+		},
+	}
+}
+
+func (d *dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if network != "tcp" {
+		return d.dialer.DialContext(ctx, network, addr)
+	}
+	if d.cache != nil {
+		return d.dialCached(ctx, addr)
+	}
+
+	return d.dialFamily(ctx, addr)
+}
+
+func (d *dialer) dialFamily(ctx context.Context, addr string) (net.Conn, error) {
+	switch d.family {
+	case AddressFamilyIPv4Only:
+		return d.dialer.DialContext(ctx, "tcp4", addr)
+	case AddressFamilyIPv6Only:
+		return d.dialer.DialContext(ctx, "tcp6", addr)
+	case AddressFamilyPreferIPv4:
+		return d.dialPreferred(ctx, "tcp4", "tcp6", addr)
+	case AddressFamilyPreferIPv6:
+		return d.dialPreferred(ctx, "tcp6", "tcp4", addr)
+	default:
+		return d.dialer.DialContext(ctx, "tcp", addr)
+	}
+}
+
+func (d *dialer) dialPreferred(ctx context.Context, preferred, fallback, addr string) (net.Conn, error) {
+	conn, err := d.dialer.DialContext(ctx, preferred, addr)
+	if err == nil {
+		return conn, nil
+	}
+
+	conn, fallbackErr := d.dialer.DialContext(ctx, fallback, addr)
+	if fallbackErr != nil {
+		return nil, fmt.Errorf("dial over %s: %w", preferred, err)
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,54 @@
+package scraper
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+func TestDialerDialContext(t *testing.T) {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("listen on IPv4 loopback: %v", err)
+	}
+	defer func() { _ = l.Close() }()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		family  AddressFamily
+		wantErr bool
+	}{
+		{name: "any", family: AddressFamilyAny},
+		{name: "ipv4 only", family: AddressFamilyIPv4Only},
+		{name: "ipv6 only", family: AddressFamilyIPv6Only, wantErr: true},
+		{name: "prefer ipv6 falls back to ipv4", family: AddressFamilyPreferIPv6},
+		{name: "prefer ipv4", family: AddressFamilyPreferIPv4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDialer()
+			d.family = tt.family
+
+			conn, err := d.DialContext(context.Background(), "tcp", l.Addr().String())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DialContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if conn != nil {
+				_ = conn.Close()
+			}
+		})
+	}
+
+	if _, err = NewHTTPClientWithRetry(1, 0, WithAddressFamily(AddressFamily(42))); err == nil {
+		t.Errorf("NewHTTPClientWithRetry() with unknown address family expected error")
+	}
+}
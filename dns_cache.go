@@ -0,0 +1,160 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+type (
+	// TTLResolver resolves host to IP addresses together with the TTL of the answer
+	TTLResolver interface {
+		LookupIP(ctx context.Context, host string) ([]net.IP, time.Duration, error)
+	}
+
+	// DNSCache is an in-process cache of resolved addresses, safe for concurrent use and sharing between clients.
+	// TTLs of answers are clamped to [minTTL, maxTTL]. Entries expire by the clock of the cache rather than
+	// the clocks of the clients, so clients sharing it agree on when they do.
+	DNSCache struct {
+		resolver TTLResolver
+		minTTL   time.Duration
+		maxTTL   time.Duration
+		clock    Clock
+
+		mu      sync.Mutex
+		entries map[string]dnsCacheEntry
+	}
+
+	dnsCacheEntry struct {
+		ips     []net.IP
+		expires time.Time
+	}
+
+	systemResolver struct {
+		resolver *net.Resolver
+		ttl      time.Duration
+	}
+)
+
+// NewSystemResolver returns a TTLResolver backed by the system resolver.
+// The standard library does not expose record TTLs, so every answer is reported with ttl.
+func NewSystemResolver(ttl time.Duration) TTLResolver {
+	return &systemResolver{resolver: net.DefaultResolver, ttl: ttl}
+}
+
+func (r *systemResolver) LookupIP(ctx context.Context, host string) ([]net.IP, time.Duration, error) {
+	addrs, err := r.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP)
+	}
+
+	return ips, r.ttl, nil
+}
+
+// NewDNSCache returns a cache of addresses resolved by resolver, clock tells when they expire and is SystemClock if nil
+func NewDNSCache(resolver TTLResolver, minTTL, maxTTL time.Duration, clock Clock) (*DNSCache, error) {
+	if resolver == nil {
+		return nil, errors.New("resolver should be not nil")
+	}
+	if minTTL < 0 || maxTTL < minTTL {
+		return nil, errors.New("TTL clamps should satisfy 0 <= minTTL <= maxTTL")
+	}
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	return &DNSCache{
+		resolver: resolver,
+		minTTL:   minTTL,
+		maxTTL:   maxTTL,
+		clock:    clock,
+		entries:  make(map[string]dnsCacheEntry),
+	}, nil
+}
+
+// LookupIP returns cached addresses of host, resolving it if there is no fresh entry
+func (c *DNSCache) LookupIP(ctx context.Context, host string) ([]net.IP, error) {
+	now := c.clock.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.ips, nil
+	}
+
+	ips, ttl, err := c.resolver.LookupIP(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, &net.DNSError{Err: "no addresses", Name: host, IsNotFound: true}
+	}
+
+	ttl = min(max(ttl, c.minTTL), c.maxTTL)
+	c.mu.Lock()
+	c.entries[host] = dnsCacheEntry{ips: ips, expires: now.Add(ttl)}
+	c.mu.Unlock()
+
+	return ips, nil
+}
+
+// dialCached resolves the host of addr through the cache and dials its addresses one by one
+// in the order defined by the address family
+func (d *dialer) dialCached(ctx context.Context, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("split address [%s]: %w", addr, err)
+	}
+	if net.ParseIP(host) != nil {
+		return d.dialFamily(ctx, addr)
+	}
+
+	ips, err := d.cache.LookupIP(ctx, host)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: err}
+	}
+
+	var v4, v6 []net.IP
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			v4 = append(v4, ip)
+		} else {
+			v6 = append(v6, ip)
+		}
+	}
+	switch d.family {
+	case AddressFamilyIPv4Only:
+		ips = v4
+	case AddressFamilyIPv6Only:
+		ips = v6
+	case AddressFamilyPreferIPv4:
+		ips = append(v4, v6...)
+	case AddressFamilyPreferIPv6:
+		ips = append(v6, v4...)
+	}
+	if len(ips) == 0 {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: &net.AddrError{Err: "no suitable address", Addr: host}}
+	}
+
+	var firstErr error
+	for _, ip := range ips {
+		conn, err := d.dialer.DialContext(ctx, "tcp", net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return nil, firstErr
+}
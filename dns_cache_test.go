@@ -0,0 +1,107 @@
+package scraper
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+type countingResolver struct {
+	ips   []net.IP
+	ttl   time.Duration
+	calls int
+}
+
+func (r *countingResolver) LookupIP(_ context.Context, _ string) ([]net.IP, time.Duration, error) {
+	r.calls++
+	return r.ips, r.ttl, nil
+}
+
+func TestDNSCacheLookupIP(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		minTTL    time.Duration
+		maxTTL    time.Duration
+		wantCalls int
+	}{
+		{name: "ttl honored", ttl: time.Hour, maxTTL: 2 * time.Hour, wantCalls: 1},
+		{name: "zero ttl raised to min", ttl: 0, minTTL: time.Minute, maxTTL: time.Hour, wantCalls: 1},
+		{name: "long ttl cut to max", ttl: time.Hour, maxTTL: 0, wantCalls: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &countingResolver{ips: []net.IP{net.IPv4(127, 0, 0, 1)}, ttl: tt.ttl}
+			cache, err := NewDNSCache(resolver, tt.minTTL, tt.maxTTL, nil)
+			if err != nil {
+				t.Fatalf("NewDNSCache() error = %v", err)
+			}
+			for i := 0; i < 3; i++ {
+				if _, err = cache.LookupIP(context.Background(), "example.test"); err != nil {
+					t.Fatalf("LookupIP() error = %v", err)
+				}
+			}
+			if resolver.calls != tt.wantCalls {
+				t.Errorf("LookupIP() resolved %d times, want %d", resolver.calls, tt.wantCalls)
+			}
+		})
+	}
+
+	if _, err := NewDNSCache(&countingResolver{}, time.Hour, time.Minute, nil); err == nil {
+		t.Errorf("NewDNSCache() with minTTL > maxTTL expected error")
+	}
+}
+
+func TestDialerDialCached(t *testing.T) {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("listen on IPv4 loopback: %v", err)
+	}
+	defer func() { _ = l.Close() }()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+
+	resolver := &countingResolver{ips: []net.IP{net.ParseIP("::1"), net.IPv4(127, 0, 0, 1)}, ttl: time.Hour}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache, _ := NewDNSCache(resolver, 0, time.Hour, ClockFunc(func() time.Time { return now }))
+
+	d := newDialer()
+	d.cache = cache
+	d.family = AddressFamilyPreferIPv4
+	for i := 0; i < 2; i++ {
+		conn, err := d.DialContext(context.Background(), "tcp", net.JoinHostPort("example.test", port))
+		if err != nil {
+			t.Fatalf("DialContext() error = %v", err)
+		}
+		_ = conn.Close()
+	}
+	if resolver.calls != 1 {
+		t.Errorf("DialContext() resolved %d times, want %d", resolver.calls, 1)
+	}
+	// cached addresses expire by the clock of the cache
+	now = now.Add(2 * time.Hour)
+	conn, err := d.DialContext(context.Background(), "tcp", net.JoinHostPort("example.test", port))
+	if err != nil {
+		t.Fatalf("DialContext() error = %v", err)
+	}
+	_ = conn.Close()
+	if resolver.calls != 2 {
+		t.Errorf("DialContext() after the TTL resolved %d times, want %d", resolver.calls, 2)
+	}
+
+	d.family = AddressFamilyIPv6Only
+	resolver.ips = []net.IP{net.IPv4(127, 0, 0, 1)}
+	cache.entries = make(map[string]dnsCacheEntry)
+	if _, err = d.DialContext(context.Background(), "tcp", net.JoinHostPort("example.test", port)); err == nil {
+		t.Errorf("DialContext() without IPv6 addresses expected error")
+	}
+}
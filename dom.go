@@ -0,0 +1,94 @@
+package scraper
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// walk visits root and all its descendants in document order until fn returns false
+func walk(root *html.Node, fn func(*html.Node) bool) {
+	if root == nil {
+		return
+	}
+	for n := root; n != nil; {
+		if !fn(n) {
+			return
+		}
+		if n.FirstChild != nil {
+			n = n.FirstChild
+			continue
+		}
+		for n != root && n.NextSibling == nil {
+			n = n.Parent
+		}
+		if n == root {
+			return
+		}
+		n = n.NextSibling
+	}
+}
+
+// elements returns all element nodes under root with the given tag name in document order
+func elements(root *html.Node, tag string) []*html.Node {
+	var nodes []*html.Node
+	walk(root, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.Data == tag {
+			nodes = append(nodes, n)
+		}
+		return true
+	})
+
+	return nodes
+}
+
+// attr returns the value of the attribute key of n and whether it is present
+func attr(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Namespace == "" && strings.EqualFold(a.Key, key) {
+			return a.Val, true
+		}
+	}
+
+	return "", false
+}
+
+// hasToken reports whether a whitespace separated attribute value like rel or class contains token
+func hasToken(value, token string) bool {
+	for _, f := range strings.Fields(value) {
+		if strings.EqualFold(f, token) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// textContent returns the concatenated text of all text nodes under n
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.TextNode {
+			b.WriteString(c.Data)
+		}
+		return true
+	})
+
+	return b.String()
+}
+
+// metaContent returns the content of the first meta tag whose name or property equals key
+func metaContent(root *html.Node, key string) (string, bool) {
+	for _, m := range elements(root, "meta") {
+		name, ok := attr(m, "name")
+		if !ok {
+			name, ok = attr(m, "property")
+		}
+		if ok && strings.EqualFold(strings.TrimSpace(name), key) {
+			content, _ := attr(m, "content")
+			return strings.TrimSpace(content), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,136 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/net/html"
+)
+
+// Element is a node paths can be evaluated from, like div[2]/span, ./span, .//a/@href or @id.
+// Relative paths have the syntax of Scraper.FindNode without the leading slash and match nodes in the subtree
+// of the element only.
+type Element struct {
+	node *html.Node
+	fold bool
+}
+
+func NewElement(node *html.Node) (*Element, error) {
+	if node == nil {
+		return nil, errors.New("node should be not nil")
+	}
+
+	return &Element{node: node}, nil
+}
+
+// FindElement returns the first node matching fullXPath as an Element. The element of a frozen Scraper is
+// a detached copy, paths evaluated from it reach its subtree only and not its parent, ancestors or siblings.
+func (s *Scraper) FindElement(fullXPath string) (*Element, error) {
+	node, err := s.FindNode(fullXPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Element{node: node, fold: s.caseInsensitive}, nil
+}
+
+// FindElements returns all nodes matching fullXPath as elements in document order, empty if there are none.
+// Like FindElement, a frozen Scraper returns elements of detached copies.
+func (s *Scraper) FindElements(fullXPath string) ([]*Element, error) {
+	nodes, err := s.FindNodes(fullXPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return elementsOf(nodes, s.caseInsensitive), nil
+}
+
+// Node returns the node of the element
+func (e *Element) Node() *html.Node {
+	return e.node
+}
+
+// Find returns the first node matching the relative path as an Element
+func (e *Element) Find(path string) (*Element, error) {
+	node, err := e.FindNode(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Element{node: node, fold: e.fold}, nil
+}
+
+// FindAll returns all nodes matching the relative path as elements in document order, empty if there are none
+func (e *Element) FindAll(path string) ([]*Element, error) {
+	nodes, err := e.FindNodes(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return elementsOf(nodes, e.fold), nil
+}
+
+// FindNode returns the first node in document order matching the relative path
+func (e *Element) FindNode(path string) (*html.Node, error) {
+	nodes, err := e.FindNodes(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("element not found")
+	}
+
+	return nodes[0], nil
+}
+
+// FindNodes returns all nodes matching the relative path in document order, empty if there are none
+func (e *Element) FindNodes(path string) ([]*html.Node, error) {
+	paths, err := parseRelativeUnion(path)
+	if err != nil {
+		return nil, err
+	}
+	if e.fold {
+		paths = foldCase(paths)
+	}
+
+	return findUnion(context.Background(), paths, e.node, nil)
+}
+
+// GetValue returns the value of the first text node matching the relative path
+func (e *Element) GetValue(path string) (string, error) {
+	node, err := e.FindNode(path)
+	if err != nil {
+		return "", err
+	}
+
+	return nodeValue(node)
+}
+
+// GetValues returns values of all text nodes matching the relative path in document order, empty if there are none.
+// It fails if any of matched nodes isn't text.
+func (e *Element) GetValues(path string) ([]string, error) {
+	nodes, err := e.FindNodes(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		value, err := nodeValue(n)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func elementsOf(nodes []*html.Node, fold bool) []*Element {
+	elements := make([]*Element, len(nodes))
+	for i, n := range nodes {
+		elements[i] = &Element{node: n, fold: fold}
+	}
+
+	return elements
+}
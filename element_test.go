@@ -0,0 +1,125 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestElementFind(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<div class="item" id="1"><div>One</div><div><span>10</span><a href="/1">more</a></div></div>
+<div class="item" id="2"><div>Two</div><div><span>20</span><span>old</span></div></div>
+</body></html>`)
+
+	items, err := s.FindElements(`//div[@class="item"]`)
+	if err != nil {
+		t.Fatalf("FindElements() error = %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("FindElements() got %d elements, want 2", len(items))
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name: "child steps",
+			path: "div[2]/span/text",
+			want: [][]string{{"10"}, {"20", "old"}},
+		},
+		{
+			name: "explicit context",
+			path: "./div[1]/text()",
+			want: [][]string{{"One"}, {"Two"}},
+		},
+		{
+			name: "descendants stay in the subtree",
+			path: ".//span[1]/text",
+			want: [][]string{{"10"}, {"20"}},
+		},
+		{
+			name: "attribute of the element",
+			path: "@id",
+			want: [][]string{{"1"}, {"2"}},
+		},
+		{
+			name: "union",
+			path: ".//a/@href | div[1]/text",
+			want: [][]string{{"One", "/1"}, {"Two"}},
+		},
+		{
+			name:    "absolute path",
+			path:    "//span",
+			wantErr: true,
+		},
+		{
+			name:    "dot without step",
+			path:    ".span",
+			wantErr: true,
+		},
+		{
+			name:    "attribute step in the middle",
+			path:    "@id/span",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, item := range items {
+				got, err := item.GetValues(tt.path)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("GetValues() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErr {
+					continue
+				}
+				if !reflect.DeepEqual(got, tt.want[i]) {
+					t.Errorf("GetValues() of item %d got = %q, want %q", i, got, tt.want[i])
+				}
+
+				value, err := item.GetValue(tt.path)
+				if err != nil {
+					t.Fatalf("GetValue() error = %v", err)
+				}
+				if value != tt.want[i][0] {
+					t.Errorf("GetValue() of item %d got = %q, want %q", i, value, tt.want[i][0])
+				}
+			}
+		})
+	}
+
+	price, err := items[1].Find("div[2]")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if got, _ := price.GetValue("span[2]/text"); got != "old" {
+		t.Errorf("GetValue() of found element got = %q, want %q", got, "old")
+	}
+	if _, err = items[0].Find("table"); err == nil {
+		t.Errorf("Find() of missing node expected error")
+	}
+	if _, err = NewElement(nil); err == nil {
+		t.Errorf("NewElement() with nil node expected error")
+	}
+}
+
+func TestElementOfFrozenScraper(t *testing.T) {
+	s := newTestScraper(t, `<html><body><ul><li>a</li><li>b</li></ul></body></html>`).Freeze()
+
+	list, err := s.FindElement("/html/body/ul")
+	if err != nil {
+		t.Fatalf("FindElement() error = %v", err)
+	}
+	node, err := list.FindNode("li[2]/text")
+	if err != nil {
+		t.Fatalf("FindNode() error = %v", err)
+	}
+	node.Data = "changed"
+
+	if got, _ := s.GetValue("/html/body/ul/li[2]/text"); got != "b" {
+		t.Errorf("GetValue() got = %q after changing a node of an element of a frozen scraper", got)
+	}
+}
@@ -0,0 +1,80 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError is returned for a call of a user function that panicked, so a single failing page doesn't stop the run
+type PanicError struct {
+	Value any
+	// Stack is the stack trace of the goroutine at the panic
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// ForEachURL calls fn for every URL in parallel with at most limit calls running at once, limit below 1 means
+// no limit. URLs not started before ctx is done fail with the error of ctx. Errors are wrapped with their URL
+// and joined in the order of urls, nil is returned if all calls succeeded. Panics of fn are recovered and returned
+// as *PanicError of their URL.
+func ForEachURL(ctx context.Context, urls []string, limit int, fn func(ctx context.Context, u string) error) error {
+	if fn == nil {
+		return errors.New("fn should be not nil")
+	}
+
+	errs := fanOut(ctx, len(urls), limit, func(ctx context.Context, i int) error {
+		return fn(ctx, urls[i])
+	})
+	for i, err := range errs {
+		if err != nil {
+			errs[i] = fmt.Errorf("process url [%s]: %w", urls[i], err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// fanOut calls fn for indexes from 0 to n with at most limit calls running at once and returns their errors by index.
+// A panic of fn is returned as *PanicError of its index.
+func fanOut(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) []error {
+	if limit < 1 || limit > n {
+		limit = n
+	}
+	errs := make([]error, n)
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			for ; i < n; i++ {
+				errs[i] = err
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() { <-sem }()
+			defer wg.Done()
+			defer func() {
+				if v := recover(); v != nil {
+					errs[i] = &PanicError{Value: v, Stack: debug.Stack()}
+				}
+			}()
+			errs[i] = fn(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,105 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachURL(t *testing.T) {
+	urls := []string{"https://a.test/", "https://b.test/", "https://c.test/", "https://d.test/", "https://e.test/"}
+	errFailed := errors.New("failed")
+
+	var (
+		running, peak atomic.Int32
+		mu            sync.Mutex
+		visited       []string
+	)
+	err := ForEachURL(context.Background(), urls, 2, func(_ context.Context, u string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		visited = append(visited, u)
+		mu.Unlock()
+		if u == "https://b.test/" || u == "https://d.test/" {
+			return errFailed
+		}
+		return nil
+	})
+
+	if len(visited) != len(urls) {
+		t.Errorf("ForEachURL() visited %d urls, want %d", len(visited), len(urls))
+	}
+	if peak.Load() > 2 {
+		t.Errorf("ForEachURL() ran %d calls at once, want at most 2", peak.Load())
+	}
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("ForEachURL() error = %v, want %v", err, errFailed)
+	}
+	want := "process url [https://b.test/]: failed\nprocess url [https://d.test/]: failed"
+	if err.Error() != want {
+		t.Errorf("ForEachURL() error = %q, want %q", err.Error(), want)
+	}
+
+	if err = ForEachURL(context.Background(), urls, 0, func(context.Context, string) error { return nil }); err != nil {
+		t.Errorf("ForEachURL() without limit error = %v", err)
+	}
+	if err = ForEachURL(context.Background(), urls, 1, nil); err == nil {
+		t.Errorf("ForEachURL() with nil fn expected error")
+	}
+}
+
+func TestForEachURLCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	err := ForEachURL(ctx, []string{"https://a.test/", "https://b.test/", "https://c.test/"}, 1, func(context.Context, string) error {
+		calls.Add(1)
+		cancel()
+		return nil
+	})
+
+	if calls.Load() != 1 {
+		t.Errorf("ForEachURL() made %d calls after cancel, want 1", calls.Load())
+	}
+	if !errors.Is(err, context.Canceled) || strings.Contains(err.Error(), "https://a.test/") {
+		t.Errorf("ForEachURL() error = %v", err)
+	}
+}
+
+func TestForEachURLRecoversPanics(t *testing.T) {
+	var calls atomic.Int32
+	err := ForEachURL(context.Background(), []string{"https://a.test/", "https://b.test/", "https://c.test/"}, 1, func(_ context.Context, u string) error {
+		calls.Add(1)
+		if u == "https://b.test/" {
+			var m map[string]int
+			m[u]++
+		}
+		return nil
+	})
+
+	if calls.Load() != 3 {
+		t.Errorf("ForEachURL() made %d calls, want 3", calls.Load())
+	}
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("ForEachURL() error = %v, want *PanicError", err)
+	}
+	if !strings.HasPrefix(err.Error(), "process url [https://b.test/]: panic: assignment to entry in nil map") {
+		t.Errorf("ForEachURL() error = %q", err.Error())
+	}
+	if !strings.Contains(string(panicErr.Stack), "TestForEachURLRecoversPanics") {
+		t.Errorf("PanicError stack doesn't contain the panicking function:\n%s", panicErr.Stack)
+	}
+}
@@ -0,0 +1,200 @@
+package scraper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrNoFavicon is returned by Favicon when the site has no icon
+var ErrNoFavicon = errors.New("favicon not found")
+
+// scalableIconWidth is the width assumed for icons of sizes "any", they scale to any size
+const scalableIconWidth = 1 << 16
+
+// Icon is an icon of a site, Width is the largest declared size, 0 if unknown
+type Icon struct {
+	URL   string
+	Rel   string
+	Sizes string
+	Type  string
+	Width int
+}
+
+type (
+	webManifest struct {
+		Icons []webManifestIcon `json:"icons"`
+	}
+
+	webManifestIcon struct {
+		Src   string `json:"src"`
+		Sizes string `json:"sizes"`
+		Type  string `json:"type"`
+	}
+)
+
+// Icons returns icons declared by link elements of the document with absolute URLs in document order:
+// icon, shortcut icon and apple-touch-icon
+func (s *Scraper) Icons() []Icon {
+	var icons []Icon
+	for _, l := range elements(s.doc, "link") {
+		rel, _ := attr(l, "rel")
+		if !hasToken(rel, "icon") && !hasToken(rel, "apple-touch-icon") && !hasToken(rel, "apple-touch-icon-precomposed") {
+			continue
+		}
+		href, _ := attr(l, "href")
+		u, err := s.absoluteURL(strings.TrimSpace(href))
+		if err != nil || strings.TrimSpace(href) == "" {
+			continue
+		}
+		sizes, _ := attr(l, "sizes")
+		iconType, _ := attr(l, "type")
+		icon := Icon{URL: u, Rel: strings.TrimSpace(rel), Sizes: strings.TrimSpace(sizes), Type: strings.TrimSpace(iconType)}
+		icon.Width = iconWidth(icon.Sizes)
+		if icon.Width == 0 && hasToken(rel, "apple-touch-icon") {
+			icon.Width = 180
+		}
+		icons = append(icons, icon)
+	}
+
+	return icons
+}
+
+// Favicon returns the largest icon of the site. Icons of link elements and of the web app manifest are considered,
+// the manifest is fetched through client. If there are none, /favicon.ico is returned if it exists.
+func (s *Scraper) Favicon(client HTTPClient) (Icon, error) {
+	if client == nil {
+		return Icon{}, errors.New("client should be not nil")
+	}
+
+	icons := s.Icons()
+	if manifest := s.manifestURL(); manifest != nil {
+		manifestIcons, err := fetchManifestIcons(client, manifest)
+		if err != nil {
+			log.Printf("fetch web app manifest error: %s", err.Error())
+		}
+		icons = append(icons, manifestIcons...)
+	}
+
+	if best, ok := largestIcon(icons); ok {
+		return best, nil
+	}
+
+	if s.url == nil {
+		return Icon{}, ErrNoFavicon
+	}
+	fallback := s.url.ResolveReference(&url.URL{Path: "/favicon.ico"})
+	resp, err := client.Get(fallback)
+	if err != nil {
+		return Icon{}, fmt.Errorf("perform GET request to url [%s]: %w", fallback, err)
+	}
+	defer func() {
+		if resp == nil || resp.Body == nil {
+			return
+		}
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Printf("resp body close error: %s", closeErr.Error())
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return Icon{}, ErrNoFavicon
+	}
+
+	return Icon{URL: fallback.String(), Rel: "icon", Type: "image/x-icon"}, nil
+}
+
+func (s *Scraper) manifestURL() *url.URL {
+	for _, l := range elements(s.doc, "link") {
+		if rel, _ := attr(l, "rel"); !hasToken(rel, "manifest") {
+			continue
+		}
+		href, _ := attr(l, "href")
+		u, err := s.absoluteURL(strings.TrimSpace(href))
+		if err != nil {
+			return nil
+		}
+		parsed, err := url.Parse(u)
+		if err != nil || !parsed.IsAbs() {
+			return nil
+		}
+		return parsed
+	}
+
+	return nil
+}
+
+func fetchManifestIcons(client HTTPClient, manifest *url.URL) ([]Icon, error) {
+	resp, err := client.Get(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("perform GET request to url [%s]: %w", manifest, err)
+	}
+	defer func() {
+		if resp == nil || resp.Body == nil {
+			return
+		}
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Printf("resp body close error: %s", closeErr.Error())
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code is not 200: %d", resp.StatusCode)
+	}
+
+	var m webManifest
+	if err = json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("decode web app manifest: %w", err)
+	}
+
+	var icons []Icon
+	for _, i := range m.Icons {
+		src, err := url.Parse(strings.TrimSpace(i.Src))
+		if err != nil || i.Src == "" {
+			continue
+		}
+		icons = append(icons, Icon{
+			URL:   manifest.ResolveReference(src).String(),
+			Rel:   "manifest",
+			Sizes: i.Sizes,
+			Type:  i.Type,
+			Width: iconWidth(i.Sizes),
+		})
+	}
+
+	return icons, nil
+}
+
+// largestIcon returns the widest icon, the first one if widths are equal
+func largestIcon(icons []Icon) (Icon, bool) {
+	var best Icon
+	for i, icon := range icons {
+		if i == 0 || icon.Width > best.Width {
+			best = icon
+		}
+	}
+
+	return best, len(icons) != 0
+}
+
+// iconWidth returns the largest width of sizes like "16x16 32x32" or "any"
+func iconWidth(sizes string) int {
+	var width int
+	for _, size := range strings.Fields(strings.ToLower(sizes)) {
+		if size == "any" {
+			return scalableIconWidth
+		}
+		w, _, ok := strings.Cut(size, "x")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(w); err == nil && n > width {
+			width = n
+		}
+	}
+
+	return width
+}
@@ -0,0 +1,97 @@
+package scraper
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// httpClientWithoutFaviconBody serves pages but responds to /favicon.ico with no body
+type httpClientWithoutFaviconBody struct {
+	httpClientWithPages
+}
+
+func (c httpClientWithoutFaviconBody) Get(u *url.URL) (*http.Response, error) {
+	if u.Path == "/favicon.ico" {
+		return &http.Response{StatusCode: http.StatusNotFound}, nil
+	}
+
+	return c.httpClientWithPages.Get(u)
+}
+
+func TestScraperFavicon(t *testing.T) {
+	client := httpClientWithPages{
+		"https://shop.test/icons": `<html><head>
+<link rel="shortcut icon" href="/favicon-32.png" sizes="16x16 32x32">
+<link rel="apple-touch-icon" href="touch.png">
+<link rel="manifest" href="/static/site.webmanifest">
+</head></html>`,
+		"https://shop.test/static/site.webmanifest": `{"icons":[{"src":"android-512.png","sizes":"512x512","type":"image/png"}]}`,
+		"https://shop.test/links":                   `<html><head><link rel="icon" href="/a.png" sizes="32x32"><link rel="icon" href="/b.svg" sizes="any"></head></html>`,
+		"https://fallback.test/":                    `<html><head><title>no icons</title></head></html>`,
+		"https://fallback.test/favicon.ico":         "ico",
+		"https://none.test/":                        `<html></html>`,
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr error
+	}{
+		{name: "manifest icon is the largest", url: "https://shop.test/icons", want: "https://shop.test/static/android-512.png"},
+		{name: "scalable icon", url: "https://shop.test/links", want: "https://shop.test/b.svg"},
+		{name: "favicon.ico fallback", url: "https://fallback.test/", want: "https://fallback.test/favicon.ico"},
+		{name: "no icon", url: "https://none.test/", wantErr: ErrNoFavicon},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.url, client)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			got, err := s.Favicon(client)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Favicon() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.URL != tt.want {
+				t.Errorf("Favicon() got = %v, want %v", got.URL, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraperFaviconFallbackWithoutBody(t *testing.T) {
+	client := httpClientWithoutFaviconBody{httpClientWithPages{"https://none.test/": `<html></html>`}}
+	s, err := New("https://none.test/", client)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err = s.Favicon(client); !errors.Is(err, ErrNoFavicon) {
+		t.Errorf("Favicon() error = %v, want %v", err, ErrNoFavicon)
+	}
+}
+
+func TestScraperIcons(t *testing.T) {
+	s, err := New("https://shop.test/icons", httpClientWithPages{"https://shop.test/icons": `<html><head>
+<link rel="shortcut icon" href="/favicon-32.png" sizes="16x16 32x32">
+<link rel="apple-touch-icon" href="touch.png">
+<link rel="stylesheet" href="/style.css">
+</head></html>`})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	icons := s.Icons()
+	if len(icons) != 2 {
+		t.Fatalf("Icons() got %d icons, want 2", len(icons))
+	}
+	if icons[0].URL != "https://shop.test/favicon-32.png" || icons[0].Width != 32 {
+		t.Errorf("Icons() first got = %+v", icons[0])
+	}
+	if icons[1].URL != "https://shop.test/touch.png" || icons[1].Width != 180 {
+		t.Errorf("Icons() second got = %+v", icons[1])
+	}
+}
@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// fingerprintShingleSize is the number of consecutive tags hashed together
+const fingerprintShingleSize = 4
+
+// LayoutFingerprint is a structural hash of a document. It depends only on the tags of elements, so it is stable
+// across text and attribute changes, and pages of similar structure have fingerprints differing in few bits.
+type LayoutFingerprint uint64
+
+// Fingerprint returns the layout fingerprint of the document: a SimHash of shingles of consecutive element tags
+// in document order. Compare fingerprints with Distance to detect redesigns, A/B variants or block pages.
+func (s *Scraper) Fingerprint() LayoutFingerprint {
+	var tags []string
+	walk(s.doc, func(n *html.Node) bool {
+		if n.Type == html.ElementNode {
+			tags = append(tags, n.Data)
+		}
+		return true
+	})
+
+	var votes [64]int
+	vote := func(shingle []string) {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(strings.Join(shingle, " ")))
+		sum := h.Sum64()
+		for i := range votes {
+			if sum&(1<<i) != 0 {
+				votes[i]++
+			} else {
+				votes[i]--
+			}
+		}
+	}
+	if len(tags) < fingerprintShingleSize {
+		vote(tags)
+	}
+	for i := 0; i+fingerprintShingleSize <= len(tags); i++ {
+		vote(tags[i : i+fingerprintShingleSize])
+	}
+
+	var f LayoutFingerprint
+	for i, v := range votes {
+		if v > 0 {
+			f |= 1 << i
+		}
+	}
+
+	return f
+}
+
+// Distance returns the number of differing bits of fingerprints from 0 for the same layout to 64
+func (f LayoutFingerprint) Distance(other LayoutFingerprint) int {
+	return bits.OnesCount64(uint64(f ^ other))
+}
+
+func (f LayoutFingerprint) String() string {
+	return fmt.Sprintf("%016x", uint64(f))
+}
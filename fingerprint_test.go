@@ -0,0 +1,32 @@
+package scraper
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestScraperFingerprint(t *testing.T) {
+	b, err := os.ReadFile("./test-data/correct.html.txt")
+	if err != nil {
+		t.Fatalf("read test page: %v", err)
+	}
+	page := string(b)
+
+	original := newTestScraper(t, page).Fingerprint()
+
+	textChanged := newTestScraper(t, strings.ReplaceAll(page, "GeForce", "Radeon")).Fingerprint()
+	if d := original.Distance(textChanged); d != 0 {
+		t.Errorf("Distance() after text change got = %v, want 0", d)
+	}
+
+	blockAdded := newTestScraper(t, strings.Replace(page, "<body >", "<body ><div><p><span>promo</span></p></div>", 1)).Fingerprint()
+	redesigned := newTestScraper(t, "<html><body><table><tr><td><form><input><input><button>go</button></form></td></tr></table></body></html>").Fingerprint()
+	if small, large := original.Distance(blockAdded), original.Distance(redesigned); small == 0 || small >= large {
+		t.Errorf("Distance() of a small change got = %v, of a redesign %v, want more than 0 and less", small, large)
+	}
+
+	if got := LayoutFingerprint(0xabc).String(); got != "0000000000000abc" {
+		t.Errorf("String() got = %v", got)
+	}
+}
@@ -0,0 +1,40 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// NewFragment creates a Scraper from an HTML fragment like a bare <li> list or <tr> rows returned by AJAX endpoints.
+// The fragment is parsed as the content of a context element, <body> if context is zero, and paths start from
+// the top level nodes of the fragment, e.g. "/li[2]/a", without the html and body wrapper.
+func NewFragment(r io.Reader, context atom.Atom, opts ...Option) (*Scraper, error) {
+	if r == nil {
+		return nil, errors.New("reader should be not nil")
+	}
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("apply option: %w", err)
+	}
+	if context == 0 {
+		context = atom.Body
+	}
+
+	return parse(r, -1, o, func(r io.Reader) (*html.Node, error) {
+		nodes, err := o.parser.ParseFragment(r, &html.Node{Type: html.ElementNode, Data: context.String(), DataAtom: context})
+		if err != nil {
+			return nil, err
+		}
+
+		doc := &html.Node{Type: html.DocumentNode}
+		for _, n := range nodes {
+			doc.AppendChild(n)
+		}
+
+		return doc, nil
+	})
+}
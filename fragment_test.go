@@ -0,0 +1,66 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html/atom"
+)
+
+func TestNewFragment(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		context   atom.Atom
+		fullXPath string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "list items",
+			content:   `<li><a>first</a></li><li><a>second</a></li>`,
+			context:   atom.Ul,
+			fullXPath: "/li[2]/a/text",
+			want:      "second",
+		},
+		{
+			name:      "table rows",
+			content:   `<tr><td>1</td><td>one</td></tr><tr><td>2</td><td>two</td></tr>`,
+			context:   atom.Tbody,
+			fullXPath: "/tr[2]/td[2]/text",
+			want:      "two",
+		},
+		{
+			name:      "default body context",
+			content:   `<div><span>text</span></div>`,
+			fullXPath: "/div/span/text",
+			want:      "text",
+		},
+		{
+			name:      "no html wrapper",
+			content:   `<div><span>text</span></div>`,
+			fullXPath: "/html/body/div/span/text",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewFragment(strings.NewReader(tt.content), tt.context)
+			if err != nil {
+				t.Fatalf("NewFragment() error = %v", err)
+			}
+			got, err := s.GetValue(tt.fullXPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetValue() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewFragment(nil, atom.Ul); err == nil {
+		t.Errorf("NewFragment() with nil reader expected error")
+	}
+}
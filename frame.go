@@ -0,0 +1,118 @@
+package scraper
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Frame is an inline sub-document of an iframe with srcdoc or of a frame, an iframe or an object with
+// a data:text/html URL
+type Frame struct {
+	// Node is the element embedding the sub-document
+	Node *html.Node
+	*Scraper
+}
+
+// Frames parses inline sub-documents embedded in the document in document order. Frames loaded from remote URLs
+// are not fetched, frames with undecodable data URLs are skipped. Sub-documents are parsed with the options of s
+// except those about fetching, so they share the parser, case-insensitive matching, traversal limits and
+// selector timings of s. Relative URLs in them resolve against the URL of s.
+func (s *Scraper) Frames() []Frame {
+	o := s.options
+	o.byteRange, o.partialBody, o.preferAMP = 0, false, false
+	// scrapers not created by New have no options, their settings are in their fields
+	if o.parser == nil {
+		o.parser = DefaultParser
+		o.limits, o.timings, o.caseInsensitive = s.limits, s.timings, s.caseInsensitive
+	}
+
+	var frames []Frame
+	walk(s.doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		var content []byte
+		switch n.Data {
+		case "iframe", "frame", "object":
+			if srcdoc, ok := attr(n, "srcdoc"); ok && n.Data == "iframe" {
+				content = []byte(srcdoc)
+				break
+			}
+			key := "src"
+			if n.Data == "object" {
+				key = "data"
+			}
+			src, _ := attr(n, key)
+			data, err := decodeHTMLDataURL(strings.TrimSpace(src))
+			if err != nil {
+				return true
+			}
+			content = data
+		default:
+			return true
+		}
+
+		sub, err := parse(bytes.NewReader(content), int64(len(content)), o, o.parser.Parse)
+		if err != nil {
+			return true
+		}
+		sub.url, sub.profile, sub.frozen = s.url, s.profile, s.frozen
+
+		node := n
+		if s.frozen {
+			node = cloneNode(n)
+		}
+		frames = append(frames, Frame{Node: node, Scraper: sub})
+
+		return true
+	})
+
+	return frames
+}
+
+// decodeHTMLDataURL returns the content of a data URL of the text/html media type
+func decodeHTMLDataURL(u string) ([]byte, error) {
+	if len(u) < len("data:") || !strings.EqualFold(u[:len("data:")], "data:") {
+		return nil, errors.New("not a data URL")
+	}
+	header, data, ok := strings.Cut(u[len("data:"):], ",")
+	if !ok {
+		return nil, errors.New("data URL has no data")
+	}
+
+	isBase64 := false
+	if h, found := strings.CutSuffix(strings.TrimSpace(header), ";base64"); found {
+		header, isBase64 = h, true
+	}
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil || mediaType != "text/html" {
+		return nil, fmt.Errorf("media type [%s] is not text/html", header)
+	}
+
+	if isBase64 {
+		unescaped, err := url.PathUnescape(data)
+		if err != nil {
+			return nil, fmt.Errorf("unescape data: %w", err)
+		}
+		decoded, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(unescaped, "="))
+		if err != nil {
+			return nil, fmt.Errorf("decode base64 data: %w", err)
+		}
+		return decoded, nil
+	}
+
+	unescaped, err := url.PathUnescape(data)
+	if err != nil {
+		return nil, fmt.Errorf("unescape data: %w", err)
+	}
+
+	return []byte(unescaped), nil
+}
@@ -0,0 +1,58 @@
+package scraper
+
+import (
+	"encoding/base64"
+	"net/url"
+	"testing"
+)
+
+func TestScraperFrames(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString([]byte("<p>base64 widget</p>"))
+	s := newTestScraper(t, `<html><body>
+<iframe srcdoc="<p>srcdoc widget</p><a href='/more'>more</a>"></iframe>
+<iframe src="https://remote.test/widget"></iframe>
+<iframe src="data:text/html;charset=utf-8,%3Cp%3Eescaped%20widget%3C%2Fp%3E"></iframe>
+<object data="data:text/html;base64,`+encoded+`"></object>
+<iframe src="data:text/plain,not html"></iframe>
+<iframe src="data:text/html;base64,%%%"></iframe>
+</body></html>`)
+	s.url, _ = url.Parse("https://shop.test/page")
+
+	frames := s.Frames()
+	want := []string{"srcdoc widget", "escaped widget", "base64 widget"}
+	if len(frames) != len(want) {
+		t.Fatalf("Frames() got %d frames, want %d", len(frames), len(want))
+	}
+	for i, frame := range frames {
+		got, err := frame.GetValue("/html/body/p/text")
+		if err != nil || got != want[i] {
+			t.Errorf("frame %d GetValue() got = %v, error = %v, want %v", i, got, err, want[i])
+		}
+	}
+	if frames[2].Node.Data != "object" {
+		t.Errorf("frame Node got = %v, want object", frames[2].Node.Data)
+	}
+	if u := frames[0].URL(); u == nil || u.String() != "https://shop.test/page" {
+		t.Errorf("frame URL() got = %v, want the URL of the document", u)
+	}
+}
+
+func TestScraperFramesParentOptions(t *testing.T) {
+	client := httpClientWithPages{"https://shop.test/": `<iframe srcdoc="<p>12 981</p>"></iframe>`}
+	parser := &replacingParser{}
+	s, err := New("https://shop.test/", client, WithParser(parser), WithCaseInsensitiveMatching())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	frames := s.Frames()
+	if len(frames) != 1 {
+		t.Fatalf("Frames() got %d frames, want 1", len(frames))
+	}
+	if parser.calls != 2 {
+		t.Errorf("parser called %d times, want 2", parser.calls)
+	}
+	if got, err := frames[0].GetValue("/HTML/Body/P/text"); err != nil || got != "TWELVE" {
+		t.Errorf("frame GetValue() got = %v, error = %v, want %v", got, err, "TWELVE")
+	}
+}
@@ -0,0 +1,90 @@
+package scraper
+
+import "golang.org/x/net/html"
+
+// Freeze returns a read-only view of s sharing the same parsed document.
+// Methods of a Scraper never modify the document, but nodes they return can be modified by the caller.
+// Methods of a frozen Scraper return detached deep copies instead, so the document can't be modified through them
+// and the view is safe to share between goroutines. Copies returned by FindNode, FindNodes, FindCompiled, FindByID,
+// Select, GetChildes and the nodes of FindElement and FindElements have no parent and siblings, so relative paths
+// like ../span, ancestor::div or following-sibling::td evaluated from them find nothing. Such paths should start
+// at the frozen Scraper, like //td[text()="Price"]/following-sibling::td. NextAfter copies the parent of the found
+// node to keep the following siblings reachable.
+// Document and Selection work on a deep copy of the whole tree. Elements are looked up by id in an index built by
+// Freeze, so the document must not be modified through s afterwards.
+func (s *Scraper) Freeze() *Scraper {
+	frozen := *s
+	frozen.frozen = true
+	if frozen.ids == nil {
+		// the document can't change under a frozen view, so elements can be looked up by id in an index
+		frozen.ids = indexIDs(s.doc)
+	}
+	return &frozen
+}
+
+// Frozen reports whether s is a read-only view returned by Freeze
+func (s *Scraper) Frozen() bool {
+	return s.frozen
+}
+
+// cloneNode returns a deep copy of n and its descendants detached from the parent and siblings of n
+func cloneNode(n *html.Node) *html.Node {
+	c := &html.Node{
+		Type:      n.Type,
+		DataAtom:  n.DataAtom,
+		Data:      n.Data,
+		Namespace: n.Namespace,
+	}
+	if n.Attr != nil {
+		c.Attr = make([]html.Attribute, len(n.Attr))
+		copy(c.Attr, n.Attr)
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.AppendChild(cloneNode(child))
+	}
+
+	return c
+}
+
+// cloneTree returns a deep copy of root and the counterparts of nodes in it, nodes not under root are skipped
+func cloneTree(root *html.Node, nodes []*html.Node) (*html.Node, []*html.Node) {
+	counterparts := make(map[*html.Node]*html.Node, len(nodes))
+	for _, n := range nodes {
+		counterparts[n] = nil
+	}
+	c := cloneNode(root)
+	var match func(original, c *html.Node)
+	match = func(original, c *html.Node) {
+		if _, ok := counterparts[original]; ok {
+			counterparts[original] = c
+		}
+		for o, cc := original.FirstChild, c.FirstChild; o != nil; o, cc = o.NextSibling, cc.NextSibling {
+			match(o, cc)
+		}
+	}
+	match(root, c)
+
+	var copies []*html.Node
+	for _, n := range nodes {
+		if cc := counterparts[n]; cc != nil {
+			copies = append(copies, cc)
+		}
+	}
+
+	return c, copies
+}
+
+// detach returns the counterpart of n in a deep copy of its parent, n itself is copied if it has no parent
+func detach(n *html.Node) *html.Node {
+	if n.Parent == nil {
+		return cloneNode(n)
+	}
+
+	parent := cloneNode(n.Parent)
+	c := parent.FirstChild
+	for original := n.Parent.FirstChild; original != n; original = original.NextSibling {
+		c = c.NextSibling
+	}
+
+	return c
+}
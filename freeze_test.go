@@ -0,0 +1,112 @@
+package scraper
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestScraperFreeze(t *testing.T) {
+	const valuePath = "/html/body/div[1]/div[1]/div[1]/div[2]/div[3]/div/div[2]/div/div[1]/div[1]/div/span[1]/span/span/span/span/span/span/span/span/span/span/span/span/text"
+
+	s, err := New("https://someAddress", &httpClientWithoutError{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	frozen := s.Freeze()
+	if s.Frozen() || !frozen.Frozen() {
+		t.Fatalf("Freeze() should return a frozen copy and keep the original unfrozen")
+	}
+
+	node, err := frozen.FindNode(valuePath)
+	if err != nil {
+		t.Fatalf("FindNode() error = %v", err)
+	}
+	node.Data = "changed"
+	if got, _ := frozen.GetValue(valuePath); got != "12 981 - 14 444" {
+		t.Errorf("document was modified through a frozen FindNode result: %v", got)
+	}
+
+	nodes, err := frozen.NextAfter("/html/body/div[1]")
+	if err != nil {
+		t.Fatalf("NextAfter() error = %v", err)
+	}
+	original, _ := s.NextAfter("/html/body/div[1]")
+	if len(nodes) != len(original) {
+		t.Errorf("NextAfter() of frozen scraper got %d nodes, want %d", len(nodes), len(original))
+	}
+	for i := range nodes {
+		if nodes[i] == original[i] {
+			t.Fatalf("NextAfter() of frozen scraper returned a shared node")
+		}
+	}
+}
+
+func TestScraperFreezeReports(t *testing.T) {
+	s := newTestScraper(t, `<div><img src="a.png"><p id="p">text</p></div>`)
+	frozen := s.Freeze()
+
+	report := frozen.AccessibilityAudit()
+	if len(report.Issues) != 1 {
+		t.Fatalf("AccessibilityAudit() got %d issues, want 1", len(report.Issues))
+	}
+	report.Issues[0].Node.Attr = nil
+	if src, _ := attr(s.AccessibilityAudit().Issues[0].Node, "src"); src != "a.png" {
+		t.Errorf("document was modified through a frozen AccessibilityAudit node")
+	}
+
+	node, _ := s.FindByID("p")
+	sel := frozen.Selection(node)
+	if sel.Length() != 1 || sel.Nodes[0] == node || sel.Text() != "text" {
+		t.Fatalf("Selection() of frozen scraper got %d nodes, want a copy of the node", sel.Length())
+	}
+	sel.Parent().SetAttr("class", "changed")
+	if _, ok := attr(node.Parent, "class"); ok {
+		t.Errorf("document was modified through a frozen Selection")
+	}
+}
+
+func TestScraperFreezeDetachedElements(t *testing.T) {
+	s := newTestScraper(t, `<table><tr><td>Price</td><td>10 USD</td></tr></table>`)
+	frozen := s.Freeze()
+
+	label, err := frozen.FindElement(`//td[text()="Price"]`)
+	if err != nil {
+		t.Fatalf("FindElement() error = %v", err)
+	}
+	if label.Node().Parent != nil || label.Node().NextSibling != nil {
+		t.Errorf("FindElement() of frozen scraper got a node attached to a tree")
+	}
+	if _, err = label.GetValue("following-sibling::td/text()"); err == nil {
+		t.Errorf("GetValue() from a frozen element expected error, siblings are not copied")
+	}
+	if got, err := frozen.GetValue(`//td[text()="Price"]/following-sibling::td/text()`); err != nil || got != "10 USD" {
+		t.Errorf("GetValue() from the frozen scraper got = %q, %v, want %q", got, err, "10 USD")
+	}
+}
+
+func TestScraperFreezeConcurrentReads(t *testing.T) {
+	s, err := New("https://someAddress", &httpClientWithoutError{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	frozen := s.Freeze()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			node, err := frozen.FindNode("/html/body/div[1]")
+			if err != nil {
+				t.Errorf("FindNode() error = %v", err)
+				return
+			}
+			node.Attr = nil
+			if _, err = frozen.GetChildes("/html/body/div[1]/div[1]"); err != nil {
+				t.Errorf("GetChildes() error = %v", err)
+			}
+			_ = frozen.SEOAudit()
+		}()
+	}
+	wg.Wait()
+}
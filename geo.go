@@ -0,0 +1,83 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type (
+	// GeoProfile bundles settings to fetch pages as seen from a specific country
+	GeoProfile struct {
+		Name string
+		// Proxy is the address of a proxy located in the country, empty to connect directly
+		Proxy          string
+		AcceptLanguage string
+		// Currency is the ISO 4217 code prices are expected in, empty if any currency is expected
+		Currency string
+	}
+
+	// profileKey is the context key of the name of the geo profile of a request made by the retry client
+	profileKey struct{}
+)
+
+// WithGeoProfile makes the client fetch pages through the proxy and with the Accept-Language of profile.
+// The profile name is recorded in attempts of the RetryRecorder and returned by Scraper.Profile of fetched pages.
+func WithGeoProfile(profile GeoProfile) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if strings.TrimSpace(profile.Name) == "" {
+			return errors.New("geo profile name should be not empty")
+		}
+		if profile.Proxy != "" {
+			proxy, err := url.Parse(profile.Proxy)
+			if err != nil {
+				return fmt.Errorf("parse proxy of geo profile [%s]: %w", profile.Name, err)
+			}
+			if proxy.Scheme == "" || proxy.Host == "" {
+				return fmt.Errorf("proxy of geo profile [%s] should be an absolute URL", profile.Name)
+			}
+			c.proxy = http.ProxyURL(proxy)
+		}
+		if profile.AcceptLanguage != "" {
+			c.setHeader("Accept-Language", profile.AcceptLanguage)
+		}
+		c.profile = profile.Name
+		return nil
+	}
+}
+
+// ProfileFromContext returns the name of the geo profile of the request made with ctx by a client created
+// WithGeoProfile, directly or for the host of the request WithHostOptions
+func ProfileFromContext(ctx context.Context) (string, bool) {
+	profile, ok := ctx.Value(profileKey{}).(string)
+	return profile, ok
+}
+
+// setProfile adds the name of the geo profile for the host of req to its context, req is returned as is
+// if there is none
+func (c *httpClientWithRetry) setProfile(req *http.Request) *http.Request {
+	profile := c.profileFor(req.URL)
+	if profile == "" {
+		return req
+	}
+
+	return req.WithContext(context.WithValue(req.Context(), profileKey{}, profile))
+}
+
+// Profile returns the name of the geo profile the document was fetched with, empty if it was fetched without one
+func (s *Scraper) Profile() string {
+	return s.profile
+}
+
+// CheckPrice returns error if the currency of price differs from the currency expected by the profile,
+// which usually means the site ignored the location and served another region
+func (p GeoProfile) CheckPrice(price Money) error {
+	if p.Currency == "" || strings.EqualFold(price.Currency, p.Currency) {
+		return nil
+	}
+
+	return fmt.Errorf("price %s is not in %s expected by geo profile [%s]", price, p.Currency, p.Name)
+}
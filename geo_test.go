@@ -0,0 +1,62 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithGeoProfile(t *testing.T) {
+	var gotHost, gotLanguage string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost, gotLanguage = r.URL.Host, r.Header.Get("Accept-Language")
+		_, _ = w.Write([]byte("<html><body><p>ok</p></body></html>"))
+	}))
+	defer proxy.Close()
+
+	recorder := NewRetryRecorder()
+	client, err := NewHTTPClientWithRetry(0, 0,
+		WithGeoProfile(GeoProfile{Name: "de", Proxy: proxy.URL, AcceptLanguage: "de-DE,de;q=0.9", Currency: "EUR"}),
+		WithPooledConnections(),
+		WithRetryRecorder(recorder),
+	)
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+
+	s, err := New("http://shop.test/", client)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if s.Profile() != "de" {
+		t.Errorf("Profile() got = %q, want %q", s.Profile(), "de")
+	}
+	if gotHost != "shop.test" {
+		t.Errorf("request did not go through the proxy of the profile, host got = %v", gotHost)
+	}
+	if gotLanguage != "de-DE,de;q=0.9" {
+		t.Errorf("Accept-Language got = %v", gotLanguage)
+	}
+	if attempts := recorder.Attempts(); len(attempts) != 1 || attempts[0].Profile != "de" {
+		t.Errorf("Attempts() got = %+v, want one attempt with profile de", attempts)
+	}
+
+	for _, profile := range []GeoProfile{{}, {Name: "bad", Proxy: "not a url"}} {
+		if _, err = NewHTTPClientWithRetry(0, 0, WithGeoProfile(profile)); err == nil {
+			t.Errorf("NewHTTPClientWithRetry() expected error for profile %+v", profile)
+		}
+	}
+}
+
+func TestGeoProfileCheckPrice(t *testing.T) {
+	profile := GeoProfile{Name: "ua", Currency: "UAH"}
+	if err := profile.CheckPrice(Money{Amount: 12981, Currency: "UAH"}); err != nil {
+		t.Errorf("CheckPrice() error = %v", err)
+	}
+	if err := profile.CheckPrice(Money{Amount: 350, Currency: "USD"}); err == nil {
+		t.Errorf("CheckPrice() expected error for another currency")
+	}
+	if err := (GeoProfile{Name: "any"}).CheckPrice(Money{Amount: 350, Currency: "USD"}); err != nil {
+		t.Errorf("CheckPrice() error = %v for a profile without currency", err)
+	}
+}
@@ -3,6 +3,9 @@ module github.com/genvmoroz/web-scraper
 go 1.22
 
 require (
+	github.com/PuerkitoBio/goquery v1.9.1
 	github.com/hashicorp/go-cleanhttp v0.5.2
 	golang.org/x/net v0.21.0
 )
+
+require github.com/andybalholm/cascadia v1.3.2
@@ -0,0 +1,58 @@
+package scraper
+
+import (
+	"errors"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Document returns a goquery document sharing the parsed tree of s, so goquery based code can query it.
+// A frozen Scraper returns a document over a deep copy of the tree.
+func (s *Scraper) Document() *goquery.Document {
+	root := s.doc
+	if s.frozen {
+		root = cloneNode(root)
+	}
+
+	doc := goquery.NewDocumentFromNode(root)
+	doc.Url = s.URL()
+
+	return doc
+}
+
+// Selection returns a goquery selection of nodes belonging to the document of s, other nodes are skipped.
+// A frozen Scraper selects the counterparts of nodes in a deep copy of the tree.
+func (s *Scraper) Selection(nodes ...*html.Node) *goquery.Selection {
+	if s.frozen {
+		root, copies := cloneTree(s.doc, nodes)
+		return goquery.NewDocumentFromNode(root).FindNodes(copies...)
+	}
+
+	return goquery.NewDocumentFromNode(s.doc).FindNodes(nodes...)
+}
+
+// NewFromDocument creates a Scraper sharing the parsed tree of a goquery document
+func NewFromDocument(doc *goquery.Document) (*Scraper, error) {
+	if doc == nil || len(doc.Nodes) == 0 {
+		return nil, errors.New("document should be not empty")
+	}
+
+	s := &Scraper{doc: doc.Nodes[0]}
+	if doc.Url != nil {
+		u := *doc.Url
+		s.url = &u
+	}
+
+	return s, nil
+}
+
+// NewFromSelection creates a Scraper over the subtree of a single node selection.
+// Paths start from the children of the selected node, e.g. "/li[2]/a" for a selected <ul>.
+func NewFromSelection(sel *goquery.Selection) (*Scraper, error) {
+	if sel == nil || sel.Length() != 1 {
+		return nil, errors.New("selection should contain exactly one node")
+	}
+
+	return &Scraper{doc: sel.Nodes[0]}, nil
+}
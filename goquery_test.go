@@ -0,0 +1,53 @@
+package scraper
+
+import (
+	"testing"
+)
+
+func TestScraperGoqueryInterop(t *testing.T) {
+	s, err := New("https://someAddress", &httpClientWithoutError{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	doc := s.Document()
+	if got := doc.Find("link[rel=canonical]").AttrOr("href", ""); got == "" {
+		t.Errorf("Document().Find() did not find the canonical link")
+	}
+	if doc.Url == nil || doc.Url.Host != "someAddress" {
+		t.Errorf("Document() Url got = %v", doc.Url)
+	}
+
+	back, err := NewFromDocument(doc)
+	if err != nil {
+		t.Fatalf("NewFromDocument() error = %v", err)
+	}
+	if back.doc != s.doc {
+		t.Errorf("NewFromDocument() does not share the parsed tree")
+	}
+
+	node, err := s.FindNode("/html/body/div[1]")
+	if err != nil {
+		t.Fatalf("FindNode() error = %v", err)
+	}
+	sel := s.Selection(node)
+	if sel.Length() != 1 || sel.Nodes[0] != node {
+		t.Fatalf("Selection() got %d nodes, want the found node", sel.Length())
+	}
+
+	sub, err := NewFromSelection(sel)
+	if err != nil {
+		t.Fatalf("NewFromSelection() error = %v", err)
+	}
+	want, _ := s.FindNode("/html/body/div[1]/div[1]/div[1]")
+	if got, err := sub.FindNode("/div[1]/div[1]"); err != nil || got != want {
+		t.Errorf("FindNode() on selection got = %v, %v", got, err)
+	}
+
+	if _, err = NewFromSelection(doc.Find("div")); err == nil {
+		t.Errorf("NewFromSelection() with many nodes expected error")
+	}
+	if s.Freeze().Document().Nodes[0] == s.doc {
+		t.Errorf("Document() of a frozen scraper shares the parsed tree")
+	}
+}
@@ -0,0 +1,155 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// hostConfig holds client settings applied to requests to hosts matching pattern
+type hostConfig struct {
+	pattern string
+	header  http.Header
+	cookies []*http.Cookie
+	proxy   func(*http.Request) (*url.URL, error)
+	auth    []hostAuth
+	timeout time.Duration
+	// profile is the name of the geo profile of the scope, recorded in attempts instead of the one of the client
+	profile string
+}
+
+// WithHostOptions scopes opts to requests to hosts matching hostPattern, like "*.example.com", so a single client
+// can talk to several sites with appropriate settings. Scoped options may set headers, cookies, proxy, auth and
+// the request timeout: WithGeoProfile, WithCookies, WithAuth and WithRequestTimeout, scopes can not be nested.
+// Settings of the scope are applied over the settings of the client, and only the first scope matching the host
+// is applied.
+func WithHostOptions(hostPattern string, opts ...ClientOption) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if err := validateHostPattern(hostPattern); err != nil {
+			return err
+		}
+
+		scoped := &httpClientWithRetry{dialer: newDialer()}
+		for _, opt := range opts {
+			if err := opt(scoped); err != nil {
+				return fmt.Errorf("apply option for host pattern [%s]: %w", hostPattern, err)
+			}
+		}
+		if scoped.client != nil || scoped.retryPolicy != nil || scoped.recorder != nil || scoped.redactedHeaders != nil || scoped.policies != nil ||
+			scoped.hostRewrites != nil || scoped.hosts != nil || scoped.signers != nil ||
+			scoped.clock != nil || scoped.sleeper != nil || scoped.requestID != nil ||
+			scoped.dialer.family != AddressFamilyAny || scoped.dialer.cache != nil || scoped.dialer.dialer.FallbackDelay != 0 {
+			return fmt.Errorf("options for host pattern [%s] may only set headers, cookies, proxy, auth and timeout", hostPattern)
+		}
+
+		c.hosts = append(c.hosts, hostConfig{
+			pattern: strings.ToLower(hostPattern),
+			header:  scoped.header,
+			cookies: scoped.cookies,
+			proxy:   scoped.proxy,
+			auth:    scoped.auth,
+			timeout: scoped.timeout,
+			profile: scoped.profile,
+		})
+		return nil
+	}
+}
+
+// WithRequestTimeout limits the time of every attempt of a request including reading the body, zero means no limit
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if timeout < 0 {
+			return errors.New("request timeout should not be negative")
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// hostConfigFor returns the first host scope matching the host of u, nil if there is none
+func (c *httpClientWithRetry) hostConfigFor(u *url.URL) *hostConfig {
+	for i := range c.hosts {
+		if matchHost(c.hosts[i].pattern, u.Hostname()) {
+			return &c.hosts[i]
+		}
+	}
+
+	return nil
+}
+
+// proxyFor returns a proxy function choosing the proxy of the host scope, fallback if the scope has none
+func (c *httpClientWithRetry) proxyFor(fallback func(*http.Request) (*url.URL, error)) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if h := c.hostConfigFor(req.URL); h != nil && h.proxy != nil {
+			return h.proxy(req)
+		}
+		if fallback == nil {
+			return nil, nil
+		}
+		return fallback(req)
+	}
+}
+
+// profileFor returns the name of the geo profile for the host of u, empty if there is none
+func (c *httpClientWithRetry) profileFor(u *url.URL) string {
+	if h := c.hostConfigFor(u); h != nil && h.profile != "" {
+		return h.profile
+	}
+
+	return c.profile
+}
+
+// timeoutFor returns the request timeout for the host of u, zero if there is none
+func (c *httpClientWithRetry) timeoutFor(u *url.URL) time.Duration {
+	if h := c.hostConfigFor(u); h != nil && h.timeout != 0 {
+		return h.timeout
+	}
+
+	return c.timeout
+}
+
+// withTimeout returns req limited by the request timeout for its host and a function releasing the timer,
+// which must be called if the attempt fails or after the response body is closed
+func (c *httpClientWithRetry) withTimeout(req *http.Request) (*http.Request, context.CancelFunc) {
+	timeout := c.timeoutFor(req.URL)
+	if timeout == 0 {
+		return req, func() {}
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+
+	return req.WithContext(ctx), cancel
+}
+
+// cancelOnClose releases the context of a request when its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// matchHost reports whether host matches the lower case pattern, where * matches any sequence of characters
+func matchHost(pattern, host string) bool {
+	ok, _ := path.Match(pattern, strings.ToLower(host))
+	return ok
+}
+
+func validateHostPattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return errors.New("host pattern should be not empty")
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("host pattern [%s]: %w", pattern, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithHostOptions(t *testing.T) {
+	type request struct {
+		host, language, auth, cookie string
+	}
+	var (
+		mu      sync.Mutex
+		got     request
+		release = make(chan struct{})
+	)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		got = request{host: r.URL.Host, language: r.Header.Get("Accept-Language"), auth: r.Header.Get("Authorization")}
+		if c, err := r.Cookie("region"); err == nil {
+			got.cookie = c.Value
+		}
+		mu.Unlock()
+		if r.URL.Hostname() == "slow.test" {
+			// blocked until the client gives up, so the scoped timeout is what ends the request
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}
+		_, _ = w.Write([]byte("<p>ok</p>"))
+	})
+	proxy := httptest.NewServer(handler)
+	defer proxy.Close()
+	direct := httptest.NewServer(handler)
+	defer direct.Close()
+	defer close(release)
+
+	recorder := NewRetryRecorder()
+	client, err := NewHTTPClientWithRetry(0, 0,
+		WithRetryRecorder(recorder),
+		WithAuth("*", BearerToken("default")),
+		WithHostOptions("*.de.test",
+			WithGeoProfile(GeoProfile{Name: "de", Proxy: proxy.URL, AcceptLanguage: "de-DE"}),
+			WithCookies(&http.Cookie{Name: "region", Value: "de"}),
+			WithAuth("*", BearerToken("de")),
+		),
+		WithHostOptions("slow.test",
+			WithGeoProfile(GeoProfile{Name: "slow", Proxy: proxy.URL}),
+			WithRequestTimeout(50*time.Millisecond),
+		),
+		WithHostOptions("*.test", WithGeoProfile(GeoProfile{Name: "proxy", Proxy: proxy.URL})),
+	)
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		want    request
+		profile string
+		wantErr bool
+	}{
+		{
+			name:    "scoped settings",
+			url:     "http://shop.de.test/",
+			want:    request{host: "shop.de.test", language: "de-DE", auth: "Bearer de", cookie: "de"},
+			profile: "de",
+		},
+		{
+			name:    "client settings not overridden by the scope",
+			url:     "http://shop.fr.test/",
+			want:    request{host: "shop.fr.test", auth: "Bearer default"},
+			profile: "proxy",
+		},
+		{
+			name:    "scoped timeout",
+			url:     "http://slow.test/",
+			profile: "slow",
+			wantErr: true,
+		},
+		{
+			name: "no proxy",
+			url:  direct.URL,
+			want: request{auth: "Bearer default"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			got = request{}
+			mu.Unlock()
+			recorder.Reset()
+			s, err := New(tt.url, client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && s.Profile() != tt.profile {
+				t.Errorf("Profile() got = %q, want %q", s.Profile(), tt.profile)
+			}
+			for _, attempt := range recorder.Attempts() {
+				if attempt.Profile != tt.profile {
+					t.Errorf("attempt profile got = %q, want %q", attempt.Profile, tt.profile)
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("request got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithHostOptionsInvalid(t *testing.T) {
+	for _, opt := range []ClientOption{
+		WithHostOptions("[", WithCookies()),
+		WithHostOptions("*.test", WithPooledConnections()),
+		WithHostOptions("*.test", WithRetryPolicy(DefaultRetryPolicy)),
+		WithHostOptions("*.test", WithAddressFamily(AddressFamilyIPv4Only)),
+		WithHostOptions("*.test", WithDeviceProfile(DeviceProfile{
+			UserAgent:    "mobile",
+			HostRewrites: map[string]string{"shop.test": "m.shop.test"},
+		})),
+		WithHostOptions("*.test", WithRequestTimeout(-time.Second)),
+		WithHostOptions("*.test", WithHostOptions("shop.test", WithCookies())),
+	} {
+		if _, err := NewHTTPClientWithRetry(0, 0, opt); err == nil {
+			t.Errorf("NewHTTPClientWithRetry() expected error")
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package scraper
+
+import (
+	"strings"
+)
+
+// Alternates returns hreflang alternates of the document with hrefs resolved against its URL.
+// Only the first alternate of each language is returned, alternates with invalid hrefs are skipped.
+func (s *Scraper) Alternates() []HreflangLink {
+	var (
+		links []HreflangLink
+		seen  = make(map[string]bool)
+	)
+	for _, l := range elements(s.doc, "link") {
+		if rel, _ := attr(l, "rel"); !hasToken(rel, "alternate") {
+			continue
+		}
+		lang, ok := attr(l, "hreflang")
+		lang = strings.TrimSpace(lang)
+		if !ok || lang == "" || seen[strings.ToLower(lang)] {
+			continue
+		}
+		href, _ := attr(l, "href")
+		if strings.TrimSpace(href) == "" {
+			continue
+		}
+		abs, err := s.absoluteURL(strings.TrimSpace(href))
+		if err != nil {
+			continue
+		}
+		seen[strings.ToLower(lang)] = true
+		links = append(links, HreflangLink{Lang: lang, Href: abs})
+	}
+
+	return links
+}
+
+// matchLocale returns the first of locales matching the hreflang lang, ok is false if none matches.
+// Languages are compared case-insensitively and a locale without a region like "en" matches "en-GB".
+func matchLocale(lang string, locales []string) (string, bool) {
+	lang = strings.ToLower(lang)
+	for _, locale := range locales {
+		l := strings.ToLower(strings.TrimSpace(locale))
+		if l == lang || (!strings.Contains(l, "-") && strings.HasPrefix(lang, l+"-")) {
+			return locale, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,97 @@
+package scraper
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestScraperAlternates(t *testing.T) {
+	client := httpClientWithPages{
+		"https://shop.test/en/item": `<html><head>
+<link rel="alternate" hreflang="en" href="/en/item">
+<link rel="alternate" hreflang="de-DE" href="https://shop.test/de/item">
+<link rel="alternate" hreflang="DE-de" href="/duplicate">
+<link rel="alternate" hreflang="uk" href="">
+<link rel="alternate" hreflang="x-default" href="../item">
+<link rel="alternate" type="application/rss+xml" href="/feed">
+</head></html>`,
+	}
+	s, err := New("https://shop.test/en/item", client)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	want := []HreflangLink{
+		{Lang: "en", Href: "https://shop.test/en/item"},
+		{Lang: "de-DE", Href: "https://shop.test/de/item"},
+		{Lang: "x-default", Href: "https://shop.test/item"},
+	}
+	if got := s.Alternates(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Alternates() got = %v, want %v", got, want)
+	}
+}
+
+func TestMatchLocale(t *testing.T) {
+	tests := []struct {
+		lang    string
+		locales []string
+		want    string
+		wantOK  bool
+	}{
+		{lang: "de-DE", locales: []string{"de"}, want: "de", wantOK: true},
+		{lang: "en-gb", locales: []string{"en-US", "en-GB"}, want: "en-GB", wantOK: true},
+		{lang: "en", locales: []string{"en-GB"}},
+		{lang: "pt-BR", locales: []string{"pt-PT"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			got, ok := matchLocale(tt.lang, tt.locales)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("matchLocale() got = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCollectorLocales(t *testing.T) {
+	alternates := `<link rel="alternate" hreflang="en" href="/en/item">
+<link rel="alternate" hreflang="de" href="/de/item">
+<link rel="alternate" hreflang="pl" href="/pl/item">
+<link rel="alternate" hreflang="fr" href="/fr/item">`
+	client := httpClientWithPages{
+		"https://shop.test/en/item": alternates + `<span class="price">10 USD</span>`,
+		"https://shop.test/de/item": alternates + `<span class="price">9 EUR</span>`,
+		"https://shop.test/pl/item": alternates + `<span class="price">40 PLN</span>`,
+	}
+
+	c, err := NewCollector(client)
+	if err != nil {
+		t.Fatalf("NewCollector() error = %v", err)
+	}
+	c.Locales = []string{"de", "pl", "fr"}
+
+	var prices []string
+	if err = c.OnHTML(".price", func(e *HTMLElement) {
+		prices = append(prices, e.Locale+":"+e.Text)
+	}); err != nil {
+		t.Fatalf("OnHTML() error = %v", err)
+	}
+	var failed []string
+	c.OnError(func(u string, _ error) {
+		failed = append(failed, u)
+	})
+
+	if err = c.Visit("https://shop.test/en/item"); err != nil {
+		t.Fatalf("Visit() error = %v", err)
+	}
+
+	sort.Strings(prices)
+	want := []string{":10 USD", "de:9 EUR", "pl:40 PLN"}
+	if !reflect.DeepEqual(prices, want) {
+		t.Errorf("prices got = %v, want %v", prices, want)
+	}
+	if len(failed) != 1 || failed[0] != "https://shop.test/fr/item" {
+		t.Errorf("OnError() got = %v, want the missing locale", failed)
+	}
+}
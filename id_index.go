@@ -0,0 +1,107 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// FindByID returns the first element in document order with the id. A frozen Scraper looks it up in an index built
+// by Freeze, so the document is not walked. Other scrapers walk it, since their document may be modified through
+// nodes they return or through Document, and an index would miss such changes. The element found by a frozen
+// Scraper is a copy without parent and siblings.
+func (s *Scraper) FindByID(id string) (*html.Node, error) {
+	var node *html.Node
+	if s.ids != nil {
+		if nodes := s.ids[id]; len(nodes) != 0 {
+			node = nodes[0]
+		}
+	} else {
+		walk(s.doc, func(n *html.Node) bool {
+			if v, ok := attr(n, "id"); ok && n.Type == html.ElementNode && v == id {
+				node = n
+			}
+			return node == nil
+		})
+	}
+	if node == nil {
+		return nil, errors.New("element not found")
+	}
+	if s.frozen {
+		return cloneNode(node), nil
+	}
+
+	return node, nil
+}
+
+// indexIDs returns elements under root by their id in document order
+func indexIDs(root *html.Node) map[string][]*html.Node {
+	ids := make(map[string][]*html.Node)
+	walk(root, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if id, ok := attr(n, "id"); ok {
+			ids[id] = append(ids[id], n)
+		}
+		return true
+	})
+
+	return ids
+}
+
+// findPath is findNodes from rootNode. Paths starting with a step selecting elements by id, like //*[@id="x"],
+// start from elements looked up in ids instead of walking the document, ids is nil if there is no index of rootNode.
+func findPath(ctx context.Context, path []PathStep, rootNode *html.Node, ids map[string][]*html.Node) ([]*html.Node, error) {
+	id, ok := "", false
+	if len(path) != 0 && ids != nil {
+		id, ok = path[0].idLookup()
+	}
+	if !ok {
+		return findNodes(ctx, path, rootNode)
+	}
+
+	var nodes []*html.Node
+	for _, n := range ids[id] {
+		if path[0].matches(n) {
+			nodes = append(nodes, n)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	found, err := findNodes(ctx, path[1:], nodes...)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) > 1 {
+		// elements sharing an id may be nested, so nodes found from them may be out of order
+		found = documentOrder(found)
+	}
+
+	return found, nil
+}
+
+// idLookup returns the id of a descendant step selecting elements only by id, like //*[@id="x"] or //div[@id='x']
+func (s PathStep) idLookup() (string, bool) {
+	if !s.Descendant || s.Axis != "" || s.fold || s.isAttribute() || len(s.predicates) != 1 {
+		return "", false
+	}
+	e, ok := s.predicates[0].(compareExpr)
+	if !ok || e.op != "=" {
+		return "", false
+	}
+	left, right := e.left, e.right
+	if _, ok = left.(literalExpr); ok {
+		left, right = right, left
+	}
+	a, isAttr := left.(attrExpr)
+	l, isLiteral := right.(literalExpr)
+	if !isAttr || !isLiteral || !strings.EqualFold(a.name, "id") {
+		return "", false
+	}
+
+	return l.value, true
+}
@@ -0,0 +1,162 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScraperFindByID(t *testing.T) {
+	page := `<html><body>
+<div id="main"><span id="price">10</span><p id="dup">a</p></div>
+<section id="dup"><p>b</p></section>
+</body></html>`
+	parsed, err := New("https://shop.test/", httpClientWithPages{"https://shop.test/": page})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for _, s := range []*Scraper{parsed.Freeze(), parsed, newTestScraper(t, page)} {
+		node, err := s.FindByID("price")
+		if err != nil || node.Data != "span" {
+			t.Errorf("FindByID() got = %v, error = %v", node, err)
+		}
+		if node, err = s.FindByID("dup"); err != nil || node.Data != "p" {
+			t.Errorf("FindByID() of duplicated id got = %v, error = %v", node, err)
+		}
+		if _, err = s.FindByID("missing"); err == nil {
+			t.Errorf("FindByID() of missing id expected error")
+		}
+	}
+
+	frozen := parsed.Freeze()
+	node, err := frozen.FindByID("price")
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	node.FirstChild.Data = "changed"
+	if got, _ := parsed.GetValue(`//*[@id="price"]/text()`); got != "10" {
+		t.Errorf("GetValue() got = %q after changing a node found in a frozen scraper", got)
+	}
+}
+
+func TestScraperGetValuesByID(t *testing.T) {
+	page := `<html><body>
+<div id="main"><span id="price">10</span><p id="dup">a</p></div>
+<section id="dup"><p>b</p></section>
+<div id="Other"><span>c</span></div>
+</body></html>`
+	parsed, err := New("https://shop.test/", httpClientWithPages{"https://shop.test/": page})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	indexed := parsed.Freeze()
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			name: "any element",
+			path: `//*[@id="price"]/text()`,
+			want: []string{"10"},
+		},
+		{
+			name: "literal first",
+			path: `//span['price'=@id]/text()`,
+			want: []string{"10"},
+		},
+		{
+			name: "tag mismatch",
+			path: `//div[@id="price"]`,
+			want: []string{},
+		},
+		{
+			name: "duplicated id",
+			path: `//*[@id="dup"]//text()`,
+			want: []string{"a", "b"},
+		},
+		{
+			name: "duplicated id filtered by tag",
+			path: `//section[@id="dup"]/p/text()`,
+			want: []string{"b"},
+		},
+		{
+			name: "ids are case sensitive",
+			path: `//div[@id="other"]`,
+			want: []string{},
+		},
+		{
+			name: "union",
+			path: `//*[@id="price"]/text() | //*[@id="Other"]/span/text()`,
+			want: []string{"10", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := indexed.GetValues(tt.path)
+			if err != nil {
+				t.Fatalf("GetValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %q, want %q", got, tt.want)
+			}
+
+			// the index must give the same nodes as walking the document
+			want, _ := newTestScraper(t, page).FindNodes(tt.path)
+			nodes, _ := indexed.FindNodes(tt.path)
+			if len(nodes) != len(want) {
+				t.Errorf("FindNodes() got %d nodes, walking the document gives %d", len(nodes), len(want))
+			}
+		})
+	}
+}
+
+func TestScraperFindByIDAfterDocumentChanges(t *testing.T) {
+	s, err := New("https://shop.test/", httpClientWithPages{"https://shop.test/": `<div id="a"><p>old</p></div>`})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	doc := s.Document()
+	doc.Find("#a").Remove()
+	doc.Find("body").AppendHtml(`<div id="b"><p>new</p></div>`)
+
+	if got, err := s.GetValue(`//*[@id="a"]/p/text()`); err == nil {
+		t.Errorf("GetValue() of a removed element got = %q, want error", got)
+	}
+	for _, path := range []string{`//*[@id="b"]/p/text()`, `//div[@id="b"]/p/text()`} {
+		if got, err := s.GetValue(path); err != nil || got != "new" {
+			t.Errorf("GetValue(%s) of an added element got = %q, error = %v", path, got, err)
+		}
+	}
+	if _, err = s.FindByID("a"); err == nil {
+		t.Errorf("FindByID() of a removed element expected error")
+	}
+
+	// a view frozen after the changes indexes the changed document
+	frozen := s.Freeze()
+	if got, err := frozen.GetValue(`//*[@id="b"]/p/text()`); err != nil || got != "new" {
+		t.Errorf("frozen GetValue() of an added element got = %q, error = %v", got, err)
+	}
+	if _, err = frozen.FindByID("a"); err == nil {
+		t.Errorf("frozen FindByID() of a removed element expected error")
+	}
+}
+
+func BenchmarkScraperFindByID(b *testing.B) {
+	parsed, _ := New("https://someAddress", &httpClientWithoutError{})
+	s := parsed.Freeze()
+	const id = "specifications"
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = s.FindNode(`//*[@id="` + id + `"]`)
+		}
+	})
+	b.Run("walk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = s.FindNode(`//*[@id="` + id + `"][1]`)
+		}
+	})
+}
@@ -0,0 +1,233 @@
+package scraper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type (
+	jsonPathStepKind int
+
+	jsonPathStep struct {
+		kind  jsonPathStepKind
+		key   string
+		index int
+		// descendant makes the step apply to the value and all values nested in it
+		descendant bool
+	}
+)
+
+const (
+	jsonPathKey jsonPathStepKind = iota
+	jsonPathIndex
+	jsonPathWildcard
+)
+
+// AttrJSON decodes JSON embedded in the attribute key of the element at fullXPath,
+// like data-product='{"id":1}'. Double escaped entities like &amp;quot; are unescaped as well.
+func (s *Scraper) AttrJSON(fullXPath, key string) (any, error) {
+	node, err := s.find(fullXPath)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := attr(node, key)
+	if !ok {
+		return nil, fmt.Errorf("element has no attribute [%s]", key)
+	}
+
+	return decodeAttrJSON(value)
+}
+
+// DataJSON returns decoded JSON of the attribute key of all elements having it in document order,
+// attributes which are not valid JSON are skipped. Category pages often carry a JSON attribute per product card.
+func (s *Scraper) DataJSON(key string) []any {
+	var values []any
+	walk(s.doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if value, ok := attr(n, key); ok {
+			if v, err := decodeAttrJSON(value); err == nil {
+				values = append(values, v)
+			}
+		}
+		return true
+	})
+
+	return values
+}
+
+func decodeAttrJSON(value string) (any, error) {
+	var v any
+	err := json.Unmarshal([]byte(value), &v)
+	if err == nil {
+		return v, nil
+	}
+	if unescaped := html.UnescapeString(value); unescaped != value {
+		if json.Unmarshal([]byte(unescaped), &v) == nil {
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("decode attribute as JSON: %w", err)
+}
+
+// JSONPath returns values of v matching path in a subset of JSONPath: $ for the root, .name and ['name'] for
+// object members, [n] for array elements with negative n counting from the end, * for all members or elements
+// and .. for recursive descent. Members of objects are visited in the order of their names.
+func JSONPath(v any, path string) ([]any, error) {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("parse JSONPath [%s]: %w", path, err)
+	}
+
+	values := []any{v}
+	for _, step := range steps {
+		var next []any
+		for _, value := range values {
+			if step.descendant {
+				for _, d := range jsonDescendants(value) {
+					next = append(next, step.apply(d)...)
+				}
+				continue
+			}
+			next = append(next, step.apply(value)...)
+		}
+		values = next
+	}
+
+	return values, nil
+}
+
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, errors.New("should start with $")
+	}
+
+	var steps []jsonPathStep
+	for i := 1; i < len(path); {
+		var step jsonPathStep
+		switch {
+		case strings.HasPrefix(path[i:], ".."):
+			step.descendant = true
+			i += 2
+		case path[i] == '.':
+			i++
+		case path[i] == '[':
+		default:
+			return nil, fmt.Errorf("unexpected symbol at %d", i)
+		}
+
+		if i >= len(path) {
+			return nil, errors.New("unexpected end")
+		}
+		if path[i] != '[' {
+			end := i
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+			name := path[i:end]
+			switch name {
+			case "":
+				return nil, fmt.Errorf("empty name at %d", i)
+			case "*":
+				step.kind = jsonPathWildcard
+			default:
+				step.kind, step.key = jsonPathKey, name
+			}
+			steps = append(steps, step)
+			i = end
+			continue
+		}
+
+		end := strings.IndexByte(path[i:], ']')
+		if end == -1 {
+			return nil, errors.New("unclosed bracket")
+		}
+		inner := strings.TrimSpace(path[i+1 : i+end])
+		i += end + 1
+		switch {
+		case inner == "*":
+			step.kind = jsonPathWildcard
+		case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+			step.kind, step.key = jsonPathKey, inner[1:len(inner)-1]
+		default:
+			n, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index [%s]: %w", inner, err)
+			}
+			step.kind, step.index = jsonPathIndex, n
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+func (step jsonPathStep) apply(v any) []any {
+	switch t := v.(type) {
+	case map[string]any:
+		switch step.kind {
+		case jsonPathKey:
+			if member, ok := t[step.key]; ok {
+				return []any{member}
+			}
+		case jsonPathWildcard:
+			keys := sortedKeys(t)
+			members := make([]any, 0, len(keys))
+			for _, key := range keys {
+				members = append(members, t[key])
+			}
+			return members
+		}
+	case []any:
+		switch step.kind {
+		case jsonPathIndex:
+			i := step.index
+			if i < 0 {
+				i += len(t)
+			}
+			if i >= 0 && i < len(t) {
+				return []any{t[i]}
+			}
+		case jsonPathWildcard:
+			return slices.Clone(t)
+		}
+	}
+
+	return nil
+}
+
+// jsonDescendants returns v and all values nested in it in depth-first order
+func jsonDescendants(v any) []any {
+	values := []any{v}
+	switch t := v.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(t) {
+			values = append(values, jsonDescendants(t[key])...)
+		}
+	case []any:
+		for _, item := range t {
+			values = append(values, jsonDescendants(item)...)
+		}
+	}
+
+	return values
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONPath(t *testing.T) {
+	var v any = map[string]any{
+		"id":    1.0,
+		"name":  "GTX 1060",
+		"tags":  []any{"gpu", "gigabyte", "6gb"},
+		"offer": map[string]any{"price": 12981.0, "seller": map[string]any{"name": "shop"}},
+		"variants": []any{
+			map[string]any{"name": "3gb", "price": 9000.0},
+			map[string]any{"name": "6gb", "price": 12981.0},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []any
+		wantErr bool
+	}{
+		{name: "root", path: "$", want: []any{v}},
+		{name: "member", path: "$.name", want: []any{"GTX 1060"}},
+		{name: "nested member", path: "$.offer.price", want: []any{12981.0}},
+		{name: "bracket member", path: "$['offer'][\"seller\"].name", want: []any{"shop"}},
+		{name: "index", path: "$.tags[1]", want: []any{"gigabyte"}},
+		{name: "negative index", path: "$.tags[-1]", want: []any{"6gb"}},
+		{name: "index out of range", path: "$.tags[5]", want: nil},
+		{name: "wildcard", path: "$.variants[*].name", want: []any{"3gb", "6gb"}},
+		{name: "object wildcard", path: "$.offer.*", want: []any{12981.0, map[string]any{"name": "shop"}}},
+		{name: "recursive descent", path: "$..price", want: []any{12981.0, 9000.0, 12981.0}},
+		{name: "missing member", path: "$.missing.name", want: nil},
+		{name: "without root", path: "name", wantErr: true},
+		{name: "unclosed bracket", path: "$.tags[1", wantErr: true},
+		{name: "invalid index", path: "$.tags[a]", wantErr: true},
+		{name: "empty name", path: "$.tags..", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSONPath(v, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSONPath() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JSONPath() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraperDataJSON(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<div class="card" data-product='{"id":1,"name":"first"}'></div>
+<div class="card" data-product="{&quot;id&quot;:2,&quot;name&quot;:&quot;second&quot;}"></div>
+<div class="card" data-product="{&amp;quot;id&amp;quot;:3}"></div>
+<div class="card" data-product="not json"></div>
+</body></html>`)
+
+	values := s.DataJSON("data-product")
+	if len(values) != 3 {
+		t.Fatalf("DataJSON() got %d values, want 3", len(values))
+	}
+	var ids []any
+	for _, value := range values {
+		id, _ := JSONPath(value, "$.id")
+		ids = append(ids, id...)
+	}
+	if !reflect.DeepEqual(ids, []any{1.0, 2.0, 3.0}) {
+		t.Errorf("DataJSON() ids got = %v", ids)
+	}
+
+	got, err := s.AttrJSON("/html/body/div[2]", "data-product")
+	if err != nil {
+		t.Fatalf("AttrJSON() error = %v", err)
+	}
+	if name, _ := JSONPath(got, "$.name"); !reflect.DeepEqual(name, []any{"second"}) {
+		t.Errorf("AttrJSON() name got = %v", name)
+	}
+	if _, err = s.AttrJSON("/html/body/div[4]", "data-product"); err == nil {
+		t.Errorf("AttrJSON() expected error for invalid JSON")
+	}
+	if _, err = s.AttrJSON("/html/body/div[1]", "data-missing"); err == nil {
+		t.Errorf("AttrJSON() expected error for missing attribute")
+	}
+}
@@ -0,0 +1,110 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Money is an amount in a currency identified by its ISO 4217 code, empty if the currency is unknown
+type Money struct {
+	Amount   float64
+	Currency string
+}
+
+var (
+	priceNumberRegex = regexp.MustCompile(`\d[\d\s\x{00a0}\x{202f}.,']*`)
+
+	// currencySymbols are checked in order, longer symbols go first so that "US$" is not taken for "$"
+	currencySymbols = []struct {
+		symbol   string
+		currency string
+	}{
+		{symbol: "US$", currency: "USD"},
+		{symbol: "грн", currency: "UAH"},
+		{symbol: "₴", currency: "UAH"},
+		{symbol: "руб", currency: "RUB"},
+		{symbol: "₽", currency: "RUB"},
+		{symbol: "zł", currency: "PLN"},
+		{symbol: "€", currency: "EUR"},
+		{symbol: "£", currency: "GBP"},
+		{symbol: "¥", currency: "JPY"},
+		{symbol: "$", currency: "USD"},
+	}
+
+	currencyCodeRegex = regexp.MustCompile(`\b[A-Z]{3}\b`)
+)
+
+func (m Money) String() string {
+	if m.Currency == "" {
+		return strconv.FormatFloat(m.Amount, 'f', 2, 64)
+	}
+	return fmt.Sprintf("%s %s", strconv.FormatFloat(m.Amount, 'f', 2, 64), m.Currency)
+}
+
+// ParsePrice parses a human formatted price like "12 981 грн", "$1,299.99" or "1.299,99 EUR".
+// The currency is detected by symbol or ISO code and left empty if absent.
+func ParsePrice(s string) (Money, error) {
+	number := priceNumberRegex.FindString(s)
+	if number == "" {
+		return Money{}, fmt.Errorf("price [%s] does not contain a number", s)
+	}
+
+	amount, err := parseAmount(number)
+	if err != nil {
+		return Money{}, fmt.Errorf("parse amount [%s]: %w", number, err)
+	}
+
+	return Money{Amount: amount, Currency: detectCurrency(s)}, nil
+}
+
+// parseAmount parses a number with arbitrary thousands and decimal separators
+func parseAmount(s string) (float64, error) {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '\'' {
+			return -1
+		}
+		return r
+	}, s)
+	s = strings.TrimRight(s, ".,")
+	if s == "" {
+		return 0, errors.New("empty amount")
+	}
+
+	lastDot, lastComma := strings.LastIndexByte(s, '.'), strings.LastIndexByte(s, ',')
+	switch {
+	case lastDot != -1 && lastComma != -1:
+		decimal, thousands := ".", ","
+		if lastComma > lastDot {
+			decimal, thousands = ",", "."
+		}
+		s = strings.ReplaceAll(s, thousands, "")
+		s = strings.Replace(s, decimal, ".", 1)
+	case lastDot != -1 || lastComma != -1:
+		sep := "."
+		if lastComma != -1 {
+			sep = ","
+		}
+		i := strings.LastIndex(s, sep)
+		if strings.Count(s, sep) > 1 || len(s)-i-1 == 3 {
+			s = strings.ReplaceAll(s, sep, "")
+		} else {
+			s = strings.Replace(s, sep, ".", 1)
+		}
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
+
+func detectCurrency(s string) string {
+	for _, c := range currencySymbols {
+		if strings.Contains(s, c.symbol) {
+			return c.currency
+		}
+	}
+
+	return currencyCodeRegex.FindString(s)
+}
@@ -0,0 +1,35 @@
+package scraper
+
+import "testing"
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		argStr  string
+		want    Money
+		wantErr bool
+	}{
+		{name: "hryvnia with spaces", argStr: "12 981 грн", want: Money{Amount: 12981, Currency: "UAH"}},
+		{name: "range takes the first", argStr: "12 981 - 14 444", want: Money{Amount: 12981}},
+		{name: "dollar with comma thousands", argStr: "$1,299.99", want: Money{Amount: 1299.99, Currency: "USD"}},
+		{name: "euro with dot thousands", argStr: "1.299,99 EUR", want: Money{Amount: 1299.99, Currency: "EUR"}},
+		{name: "decimal comma", argStr: "19,9 €", want: Money{Amount: 19.9, Currency: "EUR"}},
+		{name: "thousands only", argStr: "£2,500", want: Money{Amount: 2500, Currency: "GBP"}},
+		{name: "non breaking space", argStr: "1 000,50 zł", want: Money{Amount: 1000.5, Currency: "PLN"}},
+		{name: "plain number", argStr: "12981", want: Money{Amount: 12981}},
+		{name: "without number", argStr: "free", wantErr: true},
+		{name: "empty string", argStr: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePrice(tt.argStr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePrice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParsePrice() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package scraper
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// WithNoscriptContent makes contents of <noscript> elements queryable as elements. Parsers keep them as raw text
+// because scripting is assumed to be enabled, while sites often put crawl-friendly fallbacks like images there.
+func WithNoscriptContent() Option {
+	return func(o *options) error {
+		o.noscript = true
+		return nil
+	}
+}
+
+// expandNoscript replaces the raw text of noscript elements under root with parsed nodes
+func expandNoscript(root *html.Node) error {
+	var noscripts []*html.Node
+	walk(root, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.Data == "noscript" && n.FirstChild != nil && n.FirstChild == n.LastChild &&
+			n.FirstChild.Type == html.TextNode {
+			noscripts = append(noscripts, n)
+		}
+		return true
+	})
+
+	for _, n := range noscripts {
+		// the content is parsed in the context of the parent, noscript itself always makes the content raw text
+		context := n.Parent
+		if context == nil || context.Type != html.ElementNode {
+			context = &html.Node{Type: html.ElementNode, DataAtom: atom.Body, Data: "body"}
+		}
+		nodes, err := html.ParseFragmentWithOptions(strings.NewReader(n.FirstChild.Data), context, html.ParseOptionEnableScripting(false))
+		if err != nil {
+			return fmt.Errorf("parse noscript content: %w", err)
+		}
+		n.RemoveChild(n.FirstChild)
+		for _, c := range nodes {
+			if c.Parent != nil {
+				c.Parent.RemoveChild(c)
+			}
+			n.AppendChild(c)
+		}
+	}
+
+	return nil
+}
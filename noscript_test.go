@@ -0,0 +1,32 @@
+package scraper
+
+import "testing"
+
+func TestWithNoscriptContent(t *testing.T) {
+	page := `<html><head><noscript><link rel="stylesheet" href="/no-js.css"></noscript></head><body>
+<div class="gallery"><noscript><img src="/full.jpg" alt="product"><span class="price">12 981 грн</span></noscript></div>
+</body></html>`
+	client := httpClientWithPages{"https://shop.test/": page}
+
+	s, err := New("https://shop.test/", client)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err = s.FindNode("/html/body/div/noscript/img"); err == nil {
+		t.Errorf("FindNode() found noscript content without WithNoscriptContent")
+	}
+
+	s, err = New("https://shop.test/", client, WithNoscriptContent())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got, err := s.ImageURL("/html/body/div/noscript/img", 0); err != nil || got != "https://shop.test/full.jpg" {
+		t.Errorf("ImageURL() got = %v, error = %v", got, err)
+	}
+	if got, err := s.GetValue("/html/body/div/noscript/span/text"); err != nil || got != "12 981 грн" {
+		t.Errorf("GetValue() got = %v, error = %v", got, err)
+	}
+	if _, err = s.FindNode("/html/head/noscript/link"); err != nil {
+		t.Errorf("FindNode() in head noscript error = %v", err)
+	}
+}
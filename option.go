@@ -0,0 +1,71 @@
+package scraper
+
+import "errors"
+
+type (
+	// Option configures a Scraper created by New
+	Option func(*options) error
+
+	options struct {
+		parser Parser
+		budget *ParseBudget
+		cache  *ExtractionCache
+		limits TraversalLimits
+
+		diagnostics bool
+		partialBody bool
+		byteRange   int64
+
+		removeConsent bool
+		timings       *SelectorTimings
+		noscript      bool
+		preferAMP     bool
+
+		caseInsensitive bool
+	}
+)
+
+func newOptions(opts []Option) (options, error) {
+	o := options{parser: DefaultParser}
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return options{}, err
+		}
+	}
+
+	return o, nil
+}
+
+// WithParser sets the HTML parser backend, DefaultParser is used by default
+func WithParser(parser Parser) Option {
+	return func(o *options) error {
+		if parser == nil {
+			return errors.New("parser should be not nil")
+		}
+		o.parser = parser
+		return nil
+	}
+}
+
+// WithParseBudget makes parsing wait for budget, which is usually shared between scrapers
+func WithParseBudget(budget *ParseBudget) Option {
+	return func(o *options) error {
+		if budget == nil {
+			return errors.New("parse budget should be not nil")
+		}
+		o.budget = budget
+		return nil
+	}
+}
+
+// WithExtractionCache makes GetValue results cached in cache by the hash of the document body and the path,
+// so re-processing an unchanged document skips path evaluation
+func WithExtractionCache(cache *ExtractionCache) Option {
+	return func(o *options) error {
+		if cache == nil {
+			return errors.New("extraction cache should be not nil")
+		}
+		o.cache = cache
+		return nil
+	}
+}
@@ -0,0 +1,30 @@
+package scraper
+
+import (
+	"io"
+
+	"golang.org/x/net/html"
+)
+
+type (
+	// Parser is an HTML parser backend building a tree of x/net/html nodes.
+	// Alternative backends, e.g. a cgo based or a more tolerant streaming parser, may be plugged in WithParser.
+	Parser interface {
+		Parse(r io.Reader) (*html.Node, error)
+		// ParseFragment parses r as the content of context element and returns the top level nodes
+		ParseFragment(r io.Reader, context *html.Node) ([]*html.Node, error)
+	}
+
+	netHTMLParser struct{}
+)
+
+// DefaultParser is a Parser backed by golang.org/x/net/html
+var DefaultParser Parser = netHTMLParser{}
+
+func (netHTMLParser) Parse(r io.Reader) (*html.Node, error) {
+	return html.Parse(r)
+}
+
+func (netHTMLParser) ParseFragment(r io.Reader, context *html.Node) ([]*html.Node, error) {
+	return html.ParseFragment(r, context)
+}
@@ -0,0 +1,53 @@
+package scraper
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// replacingParser is a Parser backend replacing the price text before parsing
+type replacingParser struct {
+	calls int
+}
+
+func (p *replacingParser) Parse(r io.Reader) (*html.Node, error) {
+	p.calls++
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return html.Parse(strings.NewReader(strings.ReplaceAll(string(b), "12 981", "TWELVE")))
+}
+
+func (p *replacingParser) ParseFragment(r io.Reader, context *html.Node) ([]*html.Node, error) {
+	p.calls++
+	return html.ParseFragment(r, context)
+}
+
+func TestWithParser(t *testing.T) {
+	const valuePath = "/html/body/div[1]/div[1]/div[1]/div[2]/div[3]/div/div[2]/div/div[1]/div[1]/div/span[1]/span/span/span/span/span/span/span/span/span/span/span/span/text"
+
+	parser := &replacingParser{}
+	s, err := New("https://someAddress", &httpClientWithoutError{}, WithParser(parser))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got, _ := s.GetValue(valuePath); got != "TWELVE - 14 444" {
+		t.Errorf("GetValue() got = %v, want document parsed by the custom parser", got)
+	}
+
+	if _, err = NewFragment(strings.NewReader("<li>1</li>"), atom.Ul, WithParser(parser)); err != nil {
+		t.Fatalf("NewFragment() error = %v", err)
+	}
+	if parser.calls != 2 {
+		t.Errorf("parser called %d times, want %d", parser.calls, 2)
+	}
+
+	if _, err = New("https://someAddress", &httpClientWithoutError{}, WithParser(nil)); err == nil {
+		t.Errorf("New() with nil parser expected error")
+	}
+}
@@ -0,0 +1,36 @@
+package scraper
+
+import (
+	"errors"
+	"io"
+)
+
+// WithPartialBody makes a failure to read the body midway not fatal: the bytes received so far are parsed
+// as the whole document and the Scraper reports Truncated. The needed element is often within the first kilobytes.
+func WithPartialBody() Option {
+	return func(o *options) error {
+		o.partialBody = true
+		return nil
+	}
+}
+
+// Truncated reports whether the document was parsed from a partially read body, see WithPartialBody and WithByteRange
+func (s *Scraper) Truncated() bool {
+	return s.truncated
+}
+
+// partialReader ends the stream with io.EOF instead of a read error, remembering the error
+type partialReader struct {
+	r   io.Reader
+	err error
+}
+
+func (p *partialReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if err != nil && !errors.Is(err, io.EOF) {
+		p.err = err
+		return n, io.EOF
+	}
+
+	return n, err
+}
@@ -0,0 +1,47 @@
+package scraper
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+	"testing/iotest"
+)
+
+// httpClientWithTruncatedBody serves the first half of the test page and fails reading the rest
+type httpClientWithTruncatedBody struct{}
+
+func (*httpClientWithTruncatedBody) Get(_ *url.URL) (*http.Response, error) {
+	b, _ := os.ReadFile("./test-data/correct.html.txt")
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(io.MultiReader(bytes.NewReader(b[:len(b)/2]), iotest.ErrReader(io.ErrUnexpectedEOF))),
+	}, nil
+}
+
+func TestWithPartialBody(t *testing.T) {
+	if _, err := New("https://someAddress", &httpClientWithTruncatedBody{}); err == nil {
+		t.Fatalf("New() expected error for truncated body without WithPartialBody")
+	}
+
+	s, err := New("https://someAddress", &httpClientWithTruncatedBody{}, WithPartialBody())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !s.Truncated() {
+		t.Errorf("Truncated() got = false, want true")
+	}
+	if title := s.SEOAudit().Title; title == "" {
+		t.Errorf("SEOAudit() did not find the title in the received part")
+	}
+
+	s, err = New("https://someAddress", &httpClientWithoutError{}, WithPartialBody())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if s.Truncated() {
+		t.Errorf("Truncated() got = true for a complete body")
+	}
+}
@@ -0,0 +1,459 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	// wildcard is the name test matching elements with any tag name
+	wildcard = "*"
+	// textFunction is the name test of text nodes in paths copied from browsers,
+	// text nodes of an element matched by a trailing text() are joined
+	textFunction = "text()"
+
+	axisParent           = "parent"
+	axisAncestor         = "ancestor"
+	axisFollowingSibling = "following-sibling"
+	axisPrecedingSibling = "preceding-sibling"
+)
+
+// pathAxes are axes a step may be prefixed with, like following-sibling::td
+var pathAxes = []string{axisParent, axisAncestor, axisFollowingSibling, axisPrecedingSibling}
+
+// PathStep is a parsed step of a path: a name test, matching elements by tag name and text nodes by a name
+// starting with "text", and predicates filtering matched nodes in order.
+// Descendant steps, written after "//", match descendants instead of children.
+// Steps with an Axis, like ancestor::div or "..", the short form of parent::*, match nodes on the axis instead.
+type PathStep struct {
+	Tag        string
+	Descendant bool
+	Axis       string
+	predicates []pathExpr
+	// fold makes tag names and string comparisons in predicates case-insensitive
+	fold bool
+}
+
+// String returns the step as written in a path after its leading slash
+func (s PathStep) String() string {
+	var b strings.Builder
+	if s.Descendant {
+		b.WriteString(pathDelimiter)
+	}
+	if s.Axis != "" {
+		b.WriteString(s.Axis + "::")
+	}
+	b.WriteString(s.Tag)
+	for _, p := range s.predicates {
+		b.WriteString("[")
+		b.WriteString(p.String())
+		b.WriteString("]")
+	}
+
+	return b.String()
+}
+
+// isAttribute reports whether the step selects an attribute of the context element like @href
+func (s PathStep) isAttribute() bool {
+	return strings.HasPrefix(s.Tag, "@")
+}
+
+// matches reports whether the node passes the name test of the step, the wildcard matches any element
+func (s PathStep) matches(n *html.Node) bool {
+	return (n.Type == html.TextNode && strings.HasPrefix(s.Tag, "text")) ||
+		(n.Type == html.ElementNode && (n.Data == s.Tag || s.Tag == wildcard || (s.fold && strings.EqualFold(n.Data, s.Tag))))
+}
+
+// Path is a compiled path, parsed and validated once to be evaluated against many documents.
+// It is immutable and safe for concurrent use.
+type Path struct {
+	expr  string
+	union [][]PathStep
+}
+
+// CompilePath parses expr with the syntax of Scraper.FindNode, so it can be reused with Scraper.FindCompiled
+func CompilePath(expr string) (*Path, error) {
+	union, err := parseUnion(expr)
+	if err != nil {
+		return nil, fmt.Errorf("compile path [%s]: %w", expr, err)
+	}
+
+	return &Path{expr: expr, union: union}, nil
+}
+
+// String returns the expression the path was compiled from
+func (p *Path) String() string {
+	return p.expr
+}
+
+// ParsePath validates fullXPath and returns its steps without evaluating it.
+// It never panics and is safe for user supplied paths.
+func ParsePath(fullXPath string) ([]PathStep, error) {
+	paths, err := parseUnion(fullXPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) != 1 {
+		return nil, errors.New("union of paths is not a single path")
+	}
+
+	return paths[0], nil
+}
+
+// parseUnion parses paths separated by "|", a single path is a union of one path
+func parseUnion(expr string) ([][]PathStep, error) {
+	return parseBranches(expr, (*pathParser).absolutePath)
+}
+
+// parseRelativeUnion is parseUnion for paths evaluated from an element, like div[2]/span or .//span
+func parseRelativeUnion(expr string) ([][]PathStep, error) {
+	return parseBranches(expr, (*pathParser).relativePath)
+}
+
+func parseBranches(expr string, branch func(*pathParser) ([]PathStep, error)) ([][]PathStep, error) {
+	if !utf8.ValidString(expr) {
+		return nil, errors.New("fullXPath is not valid utf8 string")
+	}
+
+	p := &pathParser{src: expr}
+	var paths [][]PathStep
+	for {
+		p.skipSpaces()
+		steps, err := branch(p)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, steps)
+
+		p.skipSpaces()
+		if p.done() {
+			return paths, nil
+		}
+		if !p.consume('|') {
+			return nil, fmt.Errorf("unexpected symbol %q at %d", p.peek(), p.pos)
+		}
+	}
+}
+
+// pathParser is a recursive descent parser of paths and predicate expressions
+type pathParser struct {
+	src string
+	pos int
+}
+
+func (p *pathParser) done() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *pathParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *pathParser) absolutePath() ([]PathStep, error) {
+	if p.peek() != '/' {
+		return nil, fmt.Errorf("should have a prefix \"/\"")
+	}
+
+	return p.path()
+}
+
+// relativePath parses steps starting from the context element, optionally preceded by "./" or ".//"
+func (p *pathParser) relativePath() ([]PathStep, error) {
+	if !strings.HasPrefix(p.src[p.pos:], "..") && p.consume('.') {
+		if p.peek() != '/' {
+			return nil, fmt.Errorf("\".\" at %d should be followed by a step", p.pos-1)
+		}
+		return p.path()
+	}
+	if p.peek() == '/' {
+		return nil, fmt.Errorf("path at %d should be relative", p.pos)
+	}
+
+	start := p.pos
+	step, err := p.step()
+	if err != nil {
+		return nil, fmt.Errorf("parse step at %d: %w", start, err)
+	}
+	if step.isAttribute() && p.peek() == '/' {
+		return nil, fmt.Errorf("attribute step at %d should be the last step", start)
+	}
+	steps, err := p.path()
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]PathStep{step}, steps...), nil
+}
+
+// path parses steps up to the end of the source or a symbol other than "/"
+func (p *pathParser) path() ([]PathStep, error) {
+	var steps []PathStep
+	for p.consume('/') {
+		descendant := p.consume('/')
+		start := p.pos
+		step, err := p.step()
+		if err != nil {
+			return nil, fmt.Errorf("parse step at %d: %w", start, err)
+		}
+		step.Descendant = descendant
+		if step.isAttribute() && (descendant || p.peek() == '/') {
+			return nil, fmt.Errorf("attribute step at %d should be the last step and follow a single slash", start)
+		}
+		if step.Axis != "" && descendant {
+			return nil, fmt.Errorf("step with axis at %d should follow a single slash", start)
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+func (p *pathParser) consume(b byte) bool {
+	if p.peek() != b || p.done() {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func (p *pathParser) skipSpaces() {
+	for !p.done() && isHTMLSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+// checkName rejects names read by name which are in fact axes or "." steps, both unsupported at the position
+func checkName(name string) error {
+	if i := strings.Index(name, "::"); i != -1 {
+		return fmt.Errorf("axis [%s] is not supported", name[:i])
+	}
+	if name == "." {
+		return errors.New("\".\" is supported only at the start of a relative path")
+	}
+	if name[0] == '.' {
+		return fmt.Errorf("name [%s] should not start with \".\"", name)
+	}
+
+	return nil
+}
+
+// name reads a tag or attribute name, empty if there is none at the position
+func (p *pathParser) name() string {
+	start := p.pos
+	for !p.done() {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.' && r != ':' {
+			break
+		}
+		p.pos += size
+	}
+
+	return p.src[start:p.pos]
+}
+
+func (p *pathParser) step() (PathStep, error) {
+	p.skipSpaces()
+	if p.consume('@') {
+		name := p.name()
+		if name == "" {
+			return PathStep{}, errors.New("empty attribute name")
+		}
+		if err := checkName(name); err != nil {
+			return PathStep{}, err
+		}
+		p.skipSpaces()
+		return PathStep{Tag: "@" + name}, nil
+	}
+	if strings.HasPrefix(p.src[p.pos:], "..") {
+		p.pos += 2
+		p.skipSpaces()
+		return PathStep{Tag: wildcard, Axis: axisParent}, nil
+	}
+	var axis string
+	for _, candidate := range pathAxes {
+		if strings.HasPrefix(p.src[p.pos:], candidate+"::") {
+			axis, p.pos = candidate, p.pos+len(candidate)+2
+			break
+		}
+	}
+
+	tag := p.name()
+	if tag == "" && p.consume('*') {
+		tag = wildcard
+	}
+	if tag == "" {
+		return PathStep{}, errors.New("empty name")
+	}
+	if err := checkName(tag); err != nil {
+		return PathStep{}, err
+	}
+	// text() is accepted for paths copied from browsers, it matches text nodes as any name starting with "text"
+	if tag == "text" && strings.HasPrefix(p.src[p.pos:], "()") {
+		tag, p.pos = textFunction, p.pos+2
+	}
+	step := PathStep{Tag: tag, Axis: axis}
+
+	p.skipSpaces()
+	for p.consume('[') {
+		e, err := p.predicate()
+		if err != nil {
+			return PathStep{}, err
+		}
+		step.predicates = append(step.predicates, e)
+		p.skipSpaces()
+	}
+
+	return step, nil
+}
+
+func (p *pathParser) predicate() (pathExpr, error) {
+	start := p.pos
+	e, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if !p.consume(']') {
+		return nil, fmt.Errorf("predicate at %d is not closed", start)
+	}
+
+	if n, ok := e.(numberExpr); ok {
+		if n.value == 0 {
+			return nil, errors.New("the tag number should be positive")
+		}
+	}
+
+	return e, nil
+}
+
+// expr parses a comparison of sums or a single sum
+func (p *pathParser) expr() (pathExpr, error) {
+	left, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	var op string
+	for _, candidate := range []string{"!=", "<=", ">=", "=", "<", ">"} {
+		if strings.HasPrefix(p.src[p.pos:], candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return left, nil
+	}
+	p.pos += len(op)
+
+	right, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+
+	return compareExpr{op: op, left: left, right: right}, nil
+}
+
+// sum parses operands added or subtracted from left to right, like last()-1
+func (p *pathParser) sum() (pathExpr, error) {
+	e, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		p.skipSpaces()
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return e, nil
+		}
+		p.pos++
+		right, err := p.operand()
+		if err != nil {
+			return nil, err
+		}
+		e = arithExpr{op: op, left: e, right: right}
+	}
+}
+
+func (p *pathParser) operand() (pathExpr, error) {
+	p.skipSpaces()
+	switch c := p.peek(); {
+	case c == '@':
+		p.pos++
+		name := p.name()
+		if name == "" {
+			return nil, errors.New("empty attribute name")
+		}
+		return attrExpr{name: name}, nil
+	case c == '"' || c == '\'':
+		end := strings.IndexByte(p.src[p.pos+1:], c)
+		if end == -1 {
+			return nil, errors.New("string literal is not closed")
+		}
+		value := p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return literalExpr{value: value}, nil
+	case c >= '0' && c <= '9':
+		start := p.pos
+		for !p.done() && p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		n, err := strconv.ParseUint(p.src[start:p.pos], 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("convert string to int: %w", err)
+		}
+		return numberExpr{value: uint(n)}, nil
+	case isNameStart(c):
+		return p.call()
+	case p.done():
+		return nil, errors.New("unexpected end of path")
+	default:
+		return nil, fmt.Errorf("unexpected symbol %q at %d", c, p.pos)
+	}
+}
+
+// call parses a function call like contains(@class, "price")
+func (p *pathParser) call() (pathExpr, error) {
+	name := p.name()
+	f, ok := pathFunctions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s", name)
+	}
+	p.skipSpaces()
+	if !p.consume('(') {
+		return nil, fmt.Errorf("function %s should be followed by arguments in parentheses", name)
+	}
+
+	e := callExpr{name: name}
+	p.skipSpaces()
+	for !p.consume(')') {
+		if len(e.args) != 0 && !p.consume(',') {
+			return nil, fmt.Errorf("arguments of function %s are not closed", name)
+		}
+		arg, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		e.args = append(e.args, arg)
+		p.skipSpaces()
+	}
+	if len(e.args) < f.minArgs || len(e.args) > f.maxArgs {
+		return nil, fmt.Errorf("function %s takes from %d to %d arguments, got %d", name, f.minArgs, f.maxArgs, len(e.args))
+	}
+
+	return e, nil
+}
+
+func isNameStart(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
@@ -0,0 +1,367 @@
+package scraper
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+type (
+	// pathExpr is an expression of a predicate evaluated for every node matched by a step
+	pathExpr interface {
+		eval(c exprContext) any
+		String() string
+	}
+
+	// exprContext is the node an expression is evaluated for and its 1-based position among size nodes,
+	// fold makes string comparisons case-insensitive
+	exprContext struct {
+		node     *html.Node
+		position int
+		size     int
+		fold     bool
+	}
+
+	// selectedValue is the string values of nodes selected by an expression like @name or text(), nothing is
+	// selected if there are none. Comparisons hold if they hold for any of the values, and string functions
+	// called with it are called for each of them.
+	selectedValue struct {
+		values []string
+	}
+
+	// stringValues are results of a string function called for each of several selected values,
+	// they are compared like a selectedValue and are true if any of them is not empty
+	stringValues []string
+
+	numberExpr struct {
+		value uint
+	}
+
+	literalExpr struct {
+		value string
+	}
+
+	attrExpr struct {
+		name string
+	}
+
+	compareExpr struct {
+		op          string
+		left, right pathExpr
+	}
+
+	arithExpr struct {
+		op          byte
+		left, right pathExpr
+	}
+
+	callExpr struct {
+		name string
+		args []pathExpr
+	}
+
+	// pathFunction is a function callable in predicates with minArgs to maxArgs evaluated arguments,
+	// eachValue makes it called for each of several values selected by an argument
+	pathFunction struct {
+		minArgs, maxArgs int
+		eachValue        bool
+		call             func(c exprContext, args []any) any
+	}
+)
+
+var pathFunctions = map[string]pathFunction{
+	"contains": {minArgs: 2, maxArgs: 2, eachValue: true, call: func(c exprContext, args []any) any {
+		return strings.Contains(c.foldString(args[0]), c.foldString(args[1]))
+	}},
+	"starts-with": {minArgs: 2, maxArgs: 2, eachValue: true, call: func(c exprContext, args []any) any {
+		return strings.HasPrefix(c.foldString(args[0]), c.foldString(args[1]))
+	}},
+	"normalize-space": {minArgs: 0, maxArgs: 1, eachValue: true, call: func(c exprContext, args []any) any {
+		s := textContent(c.node)
+		if len(args) != 0 {
+			s = stringValue(args[0])
+		}
+		return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+		}), " ")
+	}},
+	"position": {minArgs: 0, maxArgs: 0, call: func(c exprContext, _ []any) any {
+		return uint(c.position)
+	}},
+	"last": {minArgs: 0, maxArgs: 0, call: func(c exprContext, _ []any) any {
+		return uint(c.size)
+	}},
+	"text": {minArgs: 0, maxArgs: 0, call: func(c exprContext, _ []any) any {
+		var selected selectedValue
+		for n := c.node.FirstChild; n != nil; n = n.NextSibling {
+			if n.Type == html.TextNode {
+				selected.values = append(selected.values, n.Data)
+			}
+		}
+		return selected
+	}},
+}
+
+func (e numberExpr) eval(exprContext) any {
+	return e.value
+}
+
+func (e numberExpr) String() string {
+	return strconv.FormatUint(uint64(e.value), 10)
+}
+
+func (e literalExpr) eval(exprContext) any {
+	return e.value
+}
+
+func (e literalExpr) String() string {
+	return strconv.Quote(e.value)
+}
+
+func (e attrExpr) eval(c exprContext) any {
+	value, ok := attr(c.node, e.name)
+	if !ok {
+		return selectedValue{}
+	}
+	return selectedValue{values: []string{value}}
+}
+
+func (e attrExpr) String() string {
+	return "@" + e.name
+}
+
+// eval compares operands as numbers if one of them is a number or the operator is an inequality,
+// as strings otherwise. Operands selecting several values are equal if any of their values is,
+// comparisons with nothing selected are false.
+func (e compareExpr) eval(c exprContext) any {
+	lefts, rights := exprValues(e.left.eval(c)), exprValues(e.right.eval(c))
+	for _, left := range lefts {
+		for _, right := range rights {
+			if e.compare(c, left, right) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// compare compares single values of the operands
+func (e compareExpr) compare(c exprContext, left, right any) bool {
+	if isNumber(left) || isNumber(right) || (e.op != "=" && e.op != "!=") {
+		l, r := exprNumber(left), exprNumber(right)
+		switch e.op {
+		case "=":
+			return l == r
+		case "!=":
+			return l != r
+		case "<":
+			return l < r
+		case "<=":
+			return l <= r
+		case ">":
+			return l > r
+		default:
+			return l >= r
+		}
+	}
+
+	l, r := c.foldString(left), c.foldString(right)
+	if e.op == "!=" {
+		return l != r
+	}
+	return l == r
+}
+
+func (e compareExpr) String() string {
+	return e.left.String() + e.op + e.right.String()
+}
+
+func (e arithExpr) eval(c exprContext) any {
+	l, r := exprNumber(e.left.eval(c)), exprNumber(e.right.eval(c))
+	if e.op == '-' {
+		return l - r
+	}
+	return l + r
+}
+
+func (e arithExpr) String() string {
+	return e.left.String() + string(e.op) + e.right.String()
+}
+
+func (e callExpr) eval(c exprContext) any {
+	args := make([]any, len(e.args))
+	for i, arg := range e.args {
+		args[i] = arg.eval(c)
+	}
+
+	f := pathFunctions[e.name]
+	if f.eachValue {
+		return callEach(f, c, args)
+	}
+	return f.call(c, args)
+}
+
+// callEach calls f for each of several values selected by an argument. Boolean results are true if any call
+// returns true, other results are the stringValues of all calls.
+func callEach(f pathFunction, c exprContext, args []any) any {
+	for i, arg := range args {
+		values := exprValues(arg)
+		if len(values) < 2 {
+			continue
+		}
+
+		var results stringValues
+		for _, v := range values {
+			each := append([]any(nil), args...)
+			each[i] = v
+			switch result := callEach(f, c, each).(type) {
+			case bool:
+				if result {
+					return true
+				}
+			case stringValues:
+				results = append(results, result...)
+			default:
+				results = append(results, stringValue(result))
+			}
+		}
+		if results == nil {
+			return false
+		}
+		return results
+	}
+
+	return f.call(c, args)
+}
+
+// exprValues returns the values v compares as, every selected value for a selectedValue or stringValues
+func exprValues(v any) []any {
+	var strs []string
+	switch t := v.(type) {
+	case selectedValue:
+		strs = t.values
+	case stringValues:
+		strs = t
+	default:
+		return []any{v}
+	}
+
+	values := make([]any, len(strs))
+	for i, s := range strs {
+		values[i] = s
+	}
+	return values
+}
+
+func (e callExpr) String() string {
+	args := make([]string, len(e.args))
+	for i, arg := range e.args {
+		args[i] = arg.String()
+	}
+
+	return e.name + "(" + strings.Join(args, ",") + ")"
+}
+
+// exprString converts a value to string, false if nothing is selected
+func exprString(v any) (string, bool) {
+	switch t := v.(type) {
+	case string:
+		return t, true
+	case uint:
+		return strconv.FormatUint(uint64(t), 10), true
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(t), true
+	case selectedValue:
+		if len(t.values) == 0 {
+			return "", false
+		}
+		return t.values[0], true
+	case stringValues:
+		if len(t) == 0 {
+			return "", false
+		}
+		return t[0], true
+	default:
+		return "", false
+	}
+}
+
+// stringValue converts a value to string as function arguments are converted, empty if nothing is selected
+func stringValue(v any) string {
+	s, _ := exprString(v)
+	return s
+}
+
+// foldString is stringValue lower cased if comparisons of the context are case-insensitive
+func (c exprContext) foldString(v any) string {
+	if c.fold {
+		return strings.ToLower(stringValue(v))
+	}
+	return stringValue(v)
+}
+
+// exprNumber converts a value to number, NaN if it is not a number or nothing is selected
+func exprNumber(v any) float64 {
+	switch t := v.(type) {
+	case uint:
+		return float64(t)
+	case float64:
+		return t
+	case bool:
+		if t {
+			return 1
+		}
+		return 0
+	}
+
+	s, ok := exprString(v)
+	if !ok {
+		return math.NaN()
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return math.NaN()
+	}
+
+	return n
+}
+
+func isNumber(v any) bool {
+	switch v.(type) {
+	case uint, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// matches reports whether the predicate e holds for the node: numbers select the node at that position,
+// other values are converted to boolean
+func matches(e pathExpr, c exprContext) bool {
+	switch v := e.eval(c).(type) {
+	case uint:
+		return uint(c.position) == v
+	case float64:
+		return float64(c.position) == v
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case selectedValue:
+		return len(v.values) != 0
+	case stringValues:
+		for _, s := range v {
+			if s != "" {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
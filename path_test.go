@@ -0,0 +1,969 @@
+package scraper
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "correct",
+			path: "/html/body/div[2]/text",
+			want: []string{"html", "body", "div[2]", "text"},
+		},
+		{
+			name: "tag with digit",
+			path: "/h1[3]",
+			want: []string{"h1[3]"},
+		},
+		{
+			name: "attribute predicates",
+			path: `/div[@class="price-value"]/span[@id='main'][2]/a[@href!="/"]`,
+			want: []string{`div[@class="price-value"]`, `span[@id="main"][2]`, `a[@href!="/"]`},
+		},
+		{
+			name: "spaces and text function",
+			path: "/ div [ @id = 'a' ] /text()",
+			want: []string{`div[@id="a"]`, "text()"},
+		},
+		{
+			name: "descendant",
+			path: `//span[@itemprop="price"]/text`,
+			want: []string{`/span[@itemprop="price"]`, "text"},
+		},
+		{
+			name: "wildcard",
+			path: "/html/body/*/div[2]//*[@id='x']",
+			want: []string{"html", "body", "*", "div[2]", `/*[@id="x"]`},
+		},
+		{
+			name:    "wildcard with name",
+			path:    "/html/div*",
+			wantErr: true,
+		},
+		{
+			name: "functions",
+			path: `//a[contains(@href, "/product/")]/span[ normalize-space( text() ) = 'In stock' ][starts-with(normalize-space(),"x")]`,
+			want: []string{`/a[contains(@href,"/product/")]`, `span[normalize-space(text())="In stock"][starts-with(normalize-space(),"x")]`},
+		},
+		{
+			name: "positional functions",
+			path: "/tr[last()]/td[position()<3][position() >= last()-1]",
+			want: []string{"tr[last()]", "td[position()<3][position()>=last()-1]"},
+		},
+		{
+			name: "attribute step",
+			path: `//a[@id="x"]/ @href `,
+			want: []string{`/a[@id="x"]`, "@href"},
+		},
+		{
+			name:    "attribute step in the middle",
+			path:    "/html/@lang/body",
+			wantErr: true,
+		},
+		{
+			name:    "descendant attribute step",
+			path:    "/html//@href",
+			wantErr: true,
+		},
+		{
+			name:    "attribute step with predicate",
+			path:    "/a/@href[1]",
+			wantErr: true,
+		},
+		{
+			name:    "comparison without operand",
+			path:    "/tr[position()<]",
+			wantErr: true,
+		},
+		{
+			name:    "unknown function",
+			path:    `/a[matches(@href, "x")]`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong number of arguments",
+			path:    `/a[contains(@href)]`,
+			wantErr: true,
+		},
+		{
+			name:    "unclosed arguments",
+			path:    `/a[contains(@href, "x"]`,
+			wantErr: true,
+		},
+		{
+			name:    "function without arguments",
+			path:    `/a[contains]`,
+			wantErr: true,
+		},
+		{
+			name:    "unclosed string",
+			path:    `/div[@class="x]`,
+			wantErr: true,
+		},
+		{
+			name:    "unclosed predicate",
+			path:    `/div[@class="x"`,
+			wantErr: true,
+		},
+		{
+			name:    "empty attribute name",
+			path:    `/div[@="x"]`,
+			wantErr: true,
+		},
+		{
+			name:    "trailing symbols",
+			path:    "/div[1]0",
+			wantErr: true,
+		},
+		{
+			name:    "without prefix",
+			path:    "html/body",
+			wantErr: true,
+		},
+		{
+			name:    "malformed utf8",
+			path:    "/html/\xff\xfe",
+			wantErr: true,
+		},
+		{
+			name:    "empty segment",
+			path:    "/html///body",
+			wantErr: true,
+		},
+		{
+			name:    "nested brackets",
+			path:    "/div[[[1]]]",
+			wantErr: true,
+		},
+		{
+			name:    "zero index",
+			path:    "/div[0]",
+			wantErr: true,
+		},
+		{
+			name:    "absurd index",
+			path:    "/div[99999999999999999999999999]",
+			wantErr: true,
+		},
+		{
+			name:    "union",
+			path:    "//div | //span",
+			wantErr: true,
+		},
+		{
+			name: "axes",
+			path: `//td[text()="Price"]/following-sibling::td[1]/../preceding-sibling::tr/ancestor::*[last()]`,
+			want: []string{`/td[text()="Price"]`, "following-sibling::td[1]", "parent::*", "preceding-sibling::tr", "ancestor::*[last()]"},
+		},
+		{
+			name:    "descendant step with axis",
+			path:    "//following-sibling::td",
+			wantErr: true,
+		},
+		{
+			name:    "parent with predicate",
+			path:    "/html/..[1]",
+			wantErr: true,
+		},
+		{
+			name: "names with colon and dot",
+			path: "/svg:svg/font.face/@xml:lang",
+			want: []string{"svg:svg", "font.face", "@xml:lang"},
+		},
+		{
+			name:    "unsupported child axis",
+			path:    "/html/child::body",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported descendant axis",
+			path:    "/html/descendant::a",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported self axis",
+			path:    "/html/self::node()",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported attribute axis",
+			path:    "/a/@attribute::href",
+			wantErr: true,
+		},
+		{
+			name:    "dot step",
+			path:    "/html/./body",
+			wantErr: true,
+		},
+		{
+			name:    "name starting with dot",
+			path:    "/html/.body",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := ParsePath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePath() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var got []string
+			for _, step := range steps {
+				got = append(got, step.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePath() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzParsePath(f *testing.F) {
+	for _, seed := range []string{
+		"/html/body/div[1]/text",
+		"/div[[1]]",
+		`/div[@class="a/b"][2]`,
+		"/div[18446744073709551616]",
+		"/\xff",
+		"//",
+		"/" + strings.Repeat("[", 1000),
+		"//div | //span[1] |",
+		"/html/child::body",
+		"/html/self::node()",
+		"//descendant::a",
+		"/html/./body",
+		"/.",
+		"/a/@attribute::href",
+		"/svg:svg/@xml:lang",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, path string) {
+		if _, err := parseUnion(path); err != nil {
+			return
+		}
+		steps, err := ParsePath(path)
+		if err != nil {
+			return
+		}
+		for _, step := range steps {
+			if step.Tag == "" {
+				t.Errorf("ParsePath(%q) returned invalid step %+v", path, step)
+			}
+		}
+	})
+}
+
+func FuzzFindNode(f *testing.F) {
+	s := newTestScraper(f, "<html><body><div><p>a</p><p>b</p></div><div>c</div></body></html>")
+	for _, seed := range []string{
+		"/html/body/div[1]/p[2]/text",
+		"/html/body/div[2]/text",
+		"/html/body/div[4294967296]",
+		`/html/body/div[@id="x"]/p[2]`,
+		"//div//p[1]/text",
+		"/html/*/*[2]/text",
+		`//p[contains(normalize-space(), "a")]/text`,
+		"/html/" + strings.Repeat("div/", 100),
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, path string) {
+		node, err := s.FindNode(path)
+		if err == nil && node == nil {
+			t.Errorf("FindNode(%q) returned nil node without error", path)
+		}
+	})
+}
+
+func TestScraperFindNodeAttributePredicates(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<div class="price">1</div>
+<div id="main" class="price-value">2</div>
+<div class="price-value">3</div>
+<div class="price-value" data-sale>4</div>
+</body></html>`)
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "attribute equals",
+			path: `/html/body/div[@class="price-value"]/text`,
+			want: "2",
+		},
+		{
+			name: "single quotes",
+			path: `/html/body/div[@id='main']/text`,
+			want: "2",
+		},
+		{
+			name: "position among filtered",
+			path: `/html/body/div[@class="price-value"][2]/text`,
+			want: "3",
+		},
+		{
+			name: "filter of positioned",
+			path: `/html/body/div[2][@class="price-value"]/text`,
+			want: "2",
+		},
+		{
+			name:    "filter of positioned mismatch",
+			path:    `/html/body/div[1][@class="price-value"]/text`,
+			wantErr: true,
+		},
+		{
+			name: "attribute not equals",
+			path: `/html/body/div[@class!="price"]/text`,
+			want: "2",
+		},
+		{
+			name: "attribute exists",
+			path: `/html/body/div[@data-sale]/text`,
+			want: "4",
+		},
+		{
+			name:    "missing attribute",
+			path:    `/html/body/div[@id="none"]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetValue(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetValue() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraperFindNodesDescendant(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<div id="outer"><p>a</p><div id="inner"><p>b</p><p>c</p></div><p>d</p></div>
+<section><span itemprop="price">10</span></section>
+</body></html>`)
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "from root",
+			path: `//span[@itemprop="price"]/text`,
+			want: []string{"10"},
+		},
+		{
+			name: "in the middle",
+			path: "/html/body//p/text",
+			want: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "position among siblings",
+			path: "//p[1]/text",
+			want: []string{"a", "b"},
+		},
+		{
+			name: "nested context nodes",
+			path: "//div/p/text",
+			want: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "nested descendant steps",
+			path: "//div//p[2]/text",
+			want: []string{"c", "d"},
+		},
+		{
+			name: "wildcard",
+			path: "/html/body/*/p/text",
+			want: []string{"a", "d"},
+		},
+		{
+			name: "wildcard position",
+			path: "/html/body/*[2]/*/text",
+			want: []string{"10"},
+		},
+		{
+			name: "wildcard skips text nodes",
+			path: "//div[@id='inner']/*[2]/text",
+			want: []string{"c"},
+		},
+		{
+			name:    "not found",
+			path:    "//table",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := ParsePath(tt.path)
+			if err != nil {
+				t.Fatalf("ParsePath() error = %v", err)
+			}
+			nodes, err := findNodes(context.Background(), path, s.doc)
+			if err != nil {
+				t.Fatalf("findNodes() error = %v", err)
+			}
+			var got []string
+			for _, n := range nodes {
+				got = append(got, n.Data)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findNodes() got = %v, want %v", got, tt.want)
+			}
+
+			value, err := s.GetValue(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(tt.want) != 0 && value != tt.want[0] {
+				t.Errorf("GetValue() got = %q, want %q", value, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestScraperFindNodeFunctions(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<a href="/about">About</a>
+<a href="/product/1" class="item item-1a2b">One</a>
+<a href="/product/2" class="item item-3c4d">Two</a>
+<span>  Out of
+ stock </span>
+<span>
+  In stock</span>
+<span><b>In</b> stock</span>
+<p>a<b>b</b>b<!-- note -->c</p>
+<p>Price: <b>10</b>
+  USD <!-- currency --> per item</p>
+</body></html>`)
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			name: "contains",
+			path: `//a[contains(@href, "/product/")]/text`,
+			want: []string{"One", "Two"},
+		},
+		{
+			name: "starts-with",
+			path: `//a[starts-with(@href, "/about")]/text`,
+			want: []string{"About"},
+		},
+		{
+			name: "dynamic class part",
+			path: `//a[contains(@class, "item-3")]/text`,
+			want: []string{"Two"},
+		},
+		{
+			name: "normalize-space of text",
+			path: `//span[normalize-space(text())="In stock"]/text`,
+			want: []string{"\n  In stock"},
+		},
+		{
+			name: "normalize-space of the node",
+			path: `//span[normalize-space()="In stock"]/b/text`,
+			want: []string{"In"},
+		},
+		{
+			name: "missing attribute",
+			path: `//span[contains(@class, "item")]/text`,
+		},
+		{
+			name: "text which is not the first text child",
+			path: `//p[text()="b"]/b/text`,
+			want: []string{"b"},
+		},
+		{
+			name: "any text child differs",
+			path: `//p[text()!="a"]/b/text`,
+			want: []string{"b", "10"},
+		},
+		{
+			name: "contains of a text child after an element",
+			path: `//p[contains(text(), "USD")]/b/text`,
+			want: []string{"10"},
+		},
+		{
+			name: "starts-with of a text child after a comment",
+			path: `//p[starts-with(text(), "c")]/b/text`,
+			want: []string{"b"},
+		},
+		{
+			name: "normalize-space of text children around an element and a comment",
+			path: `//p[normalize-space(text())="USD"]/b/text`,
+			want: []string{"10"},
+		},
+		{
+			name: "normalize-space of text children matching none",
+			path: `//p[normalize-space(text())="Price: 10 USD per item"]/b/text`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetValues(tt.path)
+			if err != nil {
+				t.Fatalf("GetValues() error = %v", err)
+			}
+			if len(got) == 0 {
+				got = nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraperFindNodePositionalFunctions(t *testing.T) {
+	s := newTestScraper(t, `<table>
+<tr><td>a1</td><td>a2</td><td>a3</td></tr>
+<tr><td>b1</td><td>b2</td></tr>
+<tr><td>c1</td><td>c2</td><td>c3</td><td>c4</td></tr>
+</table>`)
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			name: "last row",
+			path: "/html/body/table/tbody/tr[last()]/td/text",
+			want: []string{"c1", "c2", "c3", "c4"},
+		},
+		{
+			name: "first cells",
+			path: "/html/body/table/tbody/tr[1]/td[position()<3]/text",
+			want: []string{"a1", "a2"},
+		},
+		{
+			name: "last cell of every row",
+			path: "//tr/td[last()]/text",
+			want: []string{"a3", "b2", "c4"},
+		},
+		{
+			name: "before last",
+			path: "//tr[position()>1]/td[last()-1]/text",
+			want: []string{"b1", "c3"},
+		},
+		{
+			name: "position among filtered",
+			path: "//tr[3]/td[position()>1][last()]/text",
+			want: []string{"c4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetValues(tt.path)
+			if err != nil {
+				t.Fatalf("GetValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraperGetValueCopiedPaths(t *testing.T) {
+	s := newTestScraper(t, `<html lang="en"><body>
+<div id="price">12 981 <small>-</small> 14 444 грн</div>
+<ul><li><a href="/1">One</a></li><li><a href="/2" title="">Two</a></li></ul>
+</body></html>`)
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			name: "joined text",
+			path: `//*[@id="price"]/text()`,
+			want: []string{"12 981  14 444 грн"},
+		},
+		{
+			name: "single text node",
+			path: "/html/body/div/text()[2]",
+			want: []string{" 14 444 грн"},
+		},
+		{
+			name: "text without parentheses is not joined",
+			path: "/html/body/div/text",
+			want: []string{"12 981 ", " 14 444 грн"},
+		},
+		{
+			name: "text of every element",
+			path: "//li/a/text()",
+			want: []string{"One", "Two"},
+		},
+		{
+			name: "attribute",
+			path: "/html/@lang",
+			want: []string{"en"},
+		},
+		{
+			name: "attribute of every element",
+			path: "/html/body/ul/li/a/@href",
+			want: []string{"/1", "/2"},
+		},
+		{
+			name: "empty attribute",
+			path: "//a/@title",
+			want: []string{""},
+		},
+		{
+			name: "missing attribute",
+			path: "/html/body/ul/@href",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetValues(tt.path)
+			if err != nil {
+				t.Fatalf("GetValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %q, want %q", got, tt.want)
+			}
+
+			value, err := s.GetValue(tt.path)
+			if (err != nil) != (len(tt.want) == 0) {
+				t.Fatalf("GetValue() error = %v", err)
+			}
+			if len(tt.want) != 0 && value != tt.want[0] {
+				t.Errorf("GetValue() got = %q, want %q", value, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestParseUnion(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name: "single path",
+			path: "/html/body",
+			want: [][]string{{"html", "body"}},
+		},
+		{
+			name: "two paths",
+			path: `//div[@class="price"] | //span[@class="price-new"]/text()`,
+			want: [][]string{{`/div[@class="price"]`}, {`/span[@class="price-new"]`, "text()"}},
+		},
+		{
+			name: "attribute step before union",
+			path: "/a/@href|/b",
+			want: [][]string{{"a", "@href"}, {"b"}},
+		},
+		{
+			name:    "empty branch",
+			path:    "//div | ",
+			wantErr: true,
+		},
+		{
+			name:    "branch without prefix",
+			path:    "//div | span",
+			wantErr: true,
+		},
+		{
+			name:    "double bar",
+			path:    "//div || //span",
+			wantErr: true,
+		},
+		{
+			name:    "attribute step in the middle of a branch",
+			path:    "//span | //div/@id/a",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := parseUnion(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseUnion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var got [][]string
+			for _, steps := range paths {
+				var path []string
+				for _, step := range steps {
+					path = append(path, step.String())
+				}
+				got = append(got, path)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseUnion() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraperGetValuesUnion(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<span class="price-new">9</span>
+<div class="price">10</div>
+<div class="price" data-old="12">11</div>
+</body></html>`)
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			name: "document order",
+			path: `//div[@class="price"]/text() | //span[@class="price-new"]/text()`,
+			want: []string{"9", "10", "11"},
+		},
+		{
+			name: "without duplicates",
+			path: "//div[2]/text | //div/text | //div[1]/text",
+			want: []string{"10", "11"},
+		},
+		{
+			name: "one layout missing",
+			path: `//table//td/text | //span[@class="price-new"]/text`,
+			want: []string{"9"},
+		},
+		{
+			name: "attributes follow nodes",
+			path: "//div/@data-old | //div[1]/text",
+			want: []string{"10", "12"},
+		},
+		{
+			name: "nothing found",
+			path: "//table | //ul",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetValues(tt.path)
+			if err != nil {
+				t.Fatalf("GetValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %q, want %q", got, tt.want)
+			}
+
+			value, err := s.GetValue(tt.path)
+			if (err != nil) != (len(tt.want) == 0) {
+				t.Fatalf("GetValue() error = %v", err)
+			}
+			if len(tt.want) != 0 && value != tt.want[0] {
+				t.Errorf("GetValue() got = %q, want %q", value, tt.want[0])
+			}
+		})
+	}
+}
+
+func TestScraperFindCompiled(t *testing.T) {
+	pages := []string{
+		`<html><body><div class="price">10</div></body></html>`,
+		`<html><body><span class="price-new">9</span><div class="price">11</div></body></html>`,
+		`<html><body><p>no price</p></body></html>`,
+	}
+	p, err := CompilePath(`//div[@class="price"]/text() | //span[@class="price-new"]/text()`)
+	if err != nil {
+		t.Fatalf("CompilePath() error = %v", err)
+	}
+
+	for i, want := range []string{"10", "9", ""} {
+		s := newTestScraper(t, pages[i])
+		node, err := s.FindCompiled(p)
+		if (err != nil) != (want == "") {
+			t.Fatalf("FindCompiled() on page %d error = %v", i, err)
+		}
+		if node != nil && node.Data != want {
+			t.Errorf("FindCompiled() on page %d got = %q, want %q", i, node.Data, want)
+		}
+
+		value, _ := s.GetValue(p.String())
+		if value != want {
+			t.Errorf("GetValue() on page %d got = %q, want %q", i, value, want)
+		}
+	}
+
+	if _, err = CompilePath("html/body"); err == nil {
+		t.Error("CompilePath() error = nil for path without prefix")
+	}
+	if _, err = newTestScraper(t, pages[0]).FindCompiled(nil); err == nil {
+		t.Error("FindCompiled() error = nil for nil path")
+	}
+}
+
+func BenchmarkScraperFindCompiled(b *testing.B) {
+	s, _ := New("https://someAddress", &httpClientWithoutError{})
+	const expr = "/html/body/div[1]/div[1]/div[1]/div[2]/div[3]/div/div[2]/div/div[1]/div[1]/div/span[1]/span/span/span/span/span/span/span/span/span/span/span/span/text"
+
+	b.Run("parse every time", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = s.FindNode(expr)
+		}
+	})
+	b.Run("compiled", func(b *testing.B) {
+		p, err := CompilePath(expr)
+		if err != nil {
+			b.Fatalf("CompilePath() error = %v", err)
+		}
+		for i := 0; i < b.N; i++ {
+			_, _ = s.FindCompiled(p)
+		}
+	})
+}
+
+func TestScraperGetValuesAxes(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<table id="specs">
+<tr><td>Brand</td><td>Acme</td></tr>
+<tr><td>Price</td><td>10</td><td>USD</td></tr>
+<tr><td>Weight</td><td>2 kg</td></tr>
+</table>
+<div id="outer"><div id="inner"><span>x</span></div></div>
+</body></html>`)
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			name: "following sibling",
+			path: `//td[text()="Price"]/following-sibling::td[1]/text()`,
+			want: []string{"10"},
+		},
+		{
+			name: "all following siblings",
+			path: `//td[text()="Price"]/following-sibling::td/text()`,
+			want: []string{"10", "USD"},
+		},
+		{
+			name: "nearest preceding sibling first",
+			path: `//td[text()="USD"]/preceding-sibling::td[1]/text()`,
+			want: []string{"10"},
+		},
+		{
+			name: "preceding siblings in document order",
+			path: "//tr[3]/preceding-sibling::tr/td[1]/text()",
+			want: []string{"Brand", "Price"},
+		},
+		{
+			name: "parent",
+			path: `//td[text()="10"]/../td[1]/text()`,
+			want: []string{"Price"},
+		},
+		{
+			name: "parents without duplicates",
+			path: "//td/../../../@id",
+			want: []string{"specs"},
+		},
+		{
+			name: "nearest ancestor first",
+			path: "//span/ancestor::div[1]/@id",
+			want: []string{"inner"},
+		},
+		{
+			name: "ancestors in document order",
+			path: "//span/ancestor::div/@id",
+			want: []string{"outer", "inner"},
+		},
+		{
+			name: "nothing on the axis",
+			path: "//tr[1]/preceding-sibling::tr",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetValues(tt.path)
+			if err != nil {
+				t.Fatalf("GetValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	label, err := s.FindElement(`//td[text()="Weight"]`)
+	if err != nil {
+		t.Fatalf("FindElement() error = %v", err)
+	}
+	if got, _ := label.GetValue("following-sibling::td/text()"); got != "2 kg" {
+		t.Errorf("GetValue() of element got = %q, want %q", got, "2 kg")
+	}
+	if got, _ := label.GetValue("../../../@id"); got != "specs" {
+		t.Errorf("GetValue() of element got = %q, want %q", got, "specs")
+	}
+}
+
+func TestScraperFindNodesAttributeExists(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<a href="/1.pdf" download>one</a>
+<a href="/2">two</a>
+<a href="/3.pdf" download="">three</a>
+<img src="/placeholder.gif" data-src="/4.jpg"><img src="/5.jpg"><img data-src="">
+</body></html>`)
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{
+			name: "boolean attribute",
+			path: "//a[@download]/@href",
+			want: []string{"/1.pdf", "/3.pdf"},
+		},
+		{
+			name: "any value including empty",
+			path: "//img[@data-src]/@data-src",
+			want: []string{"/4.jpg", ""},
+		},
+		{
+			name: "missing attribute",
+			path: "//a[@target]",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetValues(tt.path)
+			if err != nil {
+				t.Fatalf("GetValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// DomainPolicy is the legal status an operator assigned to a domain
+type DomainPolicy int
+
+const (
+	DomainUnknown DomainPolicy = iota
+	DomainAllowed
+	DomainNeedsReview
+	DomainForbidden
+)
+
+type (
+	// DomainPolicies is a registry of domain policies, safe for concurrent use.
+	// A policy set for a domain applies to all its subdomains unless a more specific domain is registered.
+	DomainPolicies struct {
+		mu       sync.RWMutex
+		policies map[string]DomainPolicy
+	}
+
+	// ForbiddenDomainError is returned when a request targets a domain marked as DomainForbidden
+	ForbiddenDomainError struct {
+		Host   string
+		Domain string
+	}
+
+	policyHTTPClient struct {
+		client   HTTPClient
+		policies *DomainPolicies
+	}
+)
+
+func (p DomainPolicy) String() string {
+	switch p {
+	case DomainAllowed:
+		return "allowed"
+	case DomainNeedsReview:
+		return "needs-review"
+	case DomainForbidden:
+		return "forbidden"
+	default:
+		return "unknown"
+	}
+}
+
+func (e *ForbiddenDomainError) Error() string {
+	return fmt.Sprintf("host [%s] is forbidden by the policy of domain [%s]", e.Host, e.Domain)
+}
+
+func NewDomainPolicies() *DomainPolicies {
+	return &DomainPolicies{policies: make(map[string]DomainPolicy)}
+}
+
+func (p *DomainPolicies) Set(domain string, policy DomainPolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.policies[normalizeDomain(domain)] = policy
+}
+
+// Policy returns the policy of the most specific registered domain that host belongs to and the domain itself
+func (p *DomainPolicies) Policy(host string) (DomainPolicy, string) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for domain := normalizeDomain(host); domain != ""; {
+		if policy, ok := p.policies[domain]; ok {
+			return policy, domain
+		}
+		i := strings.IndexByte(domain, '.')
+		if i == -1 {
+			break
+		}
+		domain = domain[i+1:]
+	}
+
+	return DomainUnknown, ""
+}
+
+// Check returns *ForbiddenDomainError if host belongs to a forbidden domain
+func (p *DomainPolicies) Check(host string) error {
+	policy, domain := p.Policy(host)
+	switch policy {
+	case DomainForbidden:
+		return &ForbiddenDomainError{Host: host, Domain: domain}
+	case DomainNeedsReview:
+		log.Printf("host [%s] belongs to domain [%s] which needs review", host, domain)
+	}
+
+	return nil
+}
+
+// checkRedirect refuses redirects to forbidden domains, it is used as http.Client.CheckRedirect
+// and stops after 10 redirects like the default policy of http.Client
+func (p *DomainPolicies) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+
+	return p.Check(req.URL.Hostname())
+}
+
+// WithDomainPolicies makes the client refuse requests to forbidden domains with *ForbiddenDomainError.
+// Every hop of a redirect chain is checked before it is requested, and refused requests are not retried.
+func WithDomainPolicies(policies *DomainPolicies) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if policies == nil {
+			return errors.New("policies should be not nil")
+		}
+		c.policies = policies
+		return nil
+	}
+}
+
+func normalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// NewPolicyHTTPClient wraps client and refuses requests to forbidden domains with *ForbiddenDomainError.
+// Redirects that end up on a forbidden domain are refused as well, but only once the response is received,
+// so hops of the redirect chain are requested anyway. Create the client WithDomainPolicies to check every hop
+// before it is requested.
+func NewPolicyHTTPClient(client HTTPClient, policies *DomainPolicies) (HTTPClient, error) {
+	if client == nil {
+		return nil, errors.New("client should be not nil")
+	}
+	if policies == nil {
+		return nil, errors.New("policies should be not nil")
+	}
+
+	return &policyHTTPClient{
+		client:   client,
+		policies: policies,
+	}, nil
+}
+
+func (c *policyHTTPClient) Get(url *url.URL) (*http.Response, error) {
+	if url == nil {
+		return nil, errors.New("url cannot be nil")
+	}
+	if err := c.policies.Check(url.Hostname()); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Request == nil || resp.Request.URL == nil {
+		return resp, nil
+	}
+	if err = c.policies.Check(resp.Request.URL.Hostname()); err != nil {
+		if resp.Body != nil {
+			if closeErr := resp.Body.Close(); closeErr != nil {
+				log.Printf("resp body close error: %s", closeErr.Error())
+			}
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
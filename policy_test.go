@@ -0,0 +1,151 @@
+package scraper
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDomainPoliciesPolicy(t *testing.T) {
+	policies := NewDomainPolicies()
+	policies.Set("example.com", DomainForbidden)
+	policies.Set("Shop.Example.com.", DomainAllowed)
+	policies.Set("partner.org", DomainNeedsReview)
+
+	tests := []struct {
+		name       string
+		host       string
+		want       DomainPolicy
+		wantDomain string
+	}{
+		{
+			name:       "exact domain",
+			host:       "example.com",
+			want:       DomainForbidden,
+			wantDomain: "example.com",
+		},
+		{
+			name:       "subdomain inherits policy",
+			host:       "www.example.com",
+			want:       DomainForbidden,
+			wantDomain: "example.com",
+		},
+		{
+			name:       "more specific domain wins",
+			host:       "api.shop.example.com",
+			want:       DomainAllowed,
+			wantDomain: "shop.example.com",
+		},
+		{
+			name:       "needs review",
+			host:       "PARTNER.org",
+			want:       DomainNeedsReview,
+			wantDomain: "partner.org",
+		},
+		{
+			name: "unknown",
+			host: "hotline.ua",
+			want: DomainUnknown,
+		},
+		{
+			name: "empty host",
+			host: "",
+			want: DomainUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotDomain := policies.Policy(tt.host)
+			if got != tt.want {
+				t.Errorf("Policy() got = %v, want %v", got, tt.want)
+			}
+			if gotDomain != tt.wantDomain {
+				t.Errorf("Policy() gotDomain = %v, want %v", gotDomain, tt.wantDomain)
+			}
+		})
+	}
+}
+
+func TestPolicyHTTPClientGet(t *testing.T) {
+	policies := NewDomainPolicies()
+	policies.Set("forbidden.com", DomainForbidden)
+
+	client, err := NewPolicyHTTPClient(&httpClientWithoutError{}, policies)
+	if err != nil {
+		t.Fatalf("NewPolicyHTTPClient() error = %v", err)
+	}
+
+	forbidden, _ := url.Parse("https://www.forbidden.com/page")
+	_, err = client.Get(forbidden)
+	var forbiddenErr *ForbiddenDomainError
+	if !errors.As(err, &forbiddenErr) {
+		t.Fatalf("Get() error = %v, want *ForbiddenDomainError", err)
+	}
+	if forbiddenErr.Domain != "forbidden.com" {
+		t.Errorf("ForbiddenDomainError.Domain got = %v, want %v", forbiddenErr.Domain, "forbidden.com")
+	}
+
+	allowed, _ := url.Parse("https://someAddress")
+	resp, err := client.Get(allowed)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestWithDomainPoliciesRedirect(t *testing.T) {
+	var forbiddenHits atomic.Int32
+	forbidden := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forbiddenHits.Add(1)
+		_, _ = w.Write([]byte("<p>forbidden</p>"))
+	}))
+	defer forbidden.Close()
+	// the forbidden server is reached by the name localhost, so it is told apart from the allowed one by host
+	forbiddenURL := strings.Replace(forbidden.URL, "127.0.0.1", "localhost", 1)
+
+	allowed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/direct":
+			http.Redirect(w, r, forbiddenURL, http.StatusFound)
+		case "/chain":
+			http.Redirect(w, r, "/direct", http.StatusFound)
+		default:
+			_, _ = w.Write([]byte("<p>ok</p>"))
+		}
+	}))
+	defer allowed.Close()
+
+	policies := NewDomainPolicies()
+	policies.Set("localhost", DomainForbidden)
+	client, err := NewHTTPClientWithRetry(2, 0, WithDomainPolicies(policies))
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+
+	for _, path := range []string{"/direct", "/chain"} {
+		u, _ := url.Parse(allowed.URL + path)
+		_, err = client.Get(u)
+		var forbiddenErr *ForbiddenDomainError
+		if !errors.As(err, &forbiddenErr) {
+			t.Errorf("Get(%s) error = %v, want *ForbiddenDomainError", path, err)
+		}
+	}
+	u, _ := url.Parse(forbiddenURL)
+	if _, err = client.Get(u); err == nil {
+		t.Errorf("Get(%s) expected error", u)
+	}
+	if hits := forbiddenHits.Load(); hits != 0 {
+		t.Errorf("forbidden host got %d requests, want 0", hits)
+	}
+
+	u, _ = url.Parse(allowed.URL + "/page")
+	resp, err := client.Get(u)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,144 @@
+package scraper
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// LinkPreview is a compact summary of a page for unfurling links
+	LinkPreview struct {
+		// URL is the canonical URL of the page, the fetched one if it has none
+		URL         string
+		Title       string
+		Description string
+		Image       string
+		SiteName    string
+		Favicon     string
+	}
+
+	// Previewer builds link previews caching them for ttl, safe for concurrent use
+	Previewer struct {
+		client HTTPClient
+		ttl    time.Duration
+		clock  Clock
+
+		mu      sync.Mutex
+		entries map[string]previewEntry
+	}
+
+	previewEntry struct {
+		preview LinkPreview
+		expires time.Time
+	}
+)
+
+// NewPreviewer returns a Previewer fetching pages through client, clock tells when cached previews expire
+// and is SystemClock if nil
+func NewPreviewer(client HTTPClient, ttl time.Duration, clock Clock) (*Previewer, error) {
+	if client == nil {
+		return nil, errors.New("client should be not nil")
+	}
+	if ttl < 0 {
+		return nil, errors.New("ttl should not be negative")
+	}
+
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &Previewer{client: client, ttl: ttl, clock: clock, entries: make(map[string]previewEntry)}, nil
+}
+
+// Preview returns the preview of the page at webAddress, fetching it if it is not cached.
+// Failures are not cached.
+func (p *Previewer) Preview(webAddress string) (LinkPreview, error) {
+	now := p.clock.Now()
+
+	p.mu.Lock()
+	entry, ok := p.entries[webAddress]
+	p.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.preview, nil
+	}
+
+	s, err := New(webAddress, p.client)
+	if err != nil {
+		return LinkPreview{}, err
+	}
+	preview := s.Preview(p.client)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, e := range p.entries {
+		if !now.Before(e.expires) {
+			delete(p.entries, key)
+		}
+	}
+	if p.ttl > 0 {
+		p.entries[webAddress] = previewEntry{preview: preview, expires: now.Add(p.ttl)}
+	}
+
+	return preview, nil
+}
+
+// Preview builds the preview of the document from OpenGraph and Twitter card tags, falling back to the title,
+// the meta description and the product image. The favicon is resolved through client, it is skipped if client is nil.
+func (s *Scraper) Preview(client HTTPClient) LinkPreview {
+	seo := s.SEOAudit()
+	preview := LinkPreview{
+		URL:         s.metaURL("og:url"),
+		Title:       firstNonEmpty(s.meta("og:title"), s.meta("twitter:title"), seo.Title),
+		Description: firstNonEmpty(s.meta("og:description"), s.meta("twitter:description"), seo.MetaDescription),
+		Image:       firstNonEmpty(s.metaURL("og:image"), s.metaURL("og:image:url"), s.metaURL("twitter:image")),
+		SiteName:    s.meta("og:site_name"),
+	}
+	if preview.URL == "" && seo.Canonical != "" {
+		preview.URL, _ = s.absoluteURL(seo.Canonical)
+	}
+	if preview.URL == "" {
+		preview.URL = seo.URL
+	}
+	if preview.Image == "" {
+		if product, err := s.Product(); err == nil && product.Image != "" {
+			preview.Image, _ = s.absoluteURL(product.Image)
+		}
+	}
+	if client != nil {
+		if icon, err := s.Favicon(client); err == nil {
+			preview.Favicon = icon.URL
+		}
+	}
+
+	return preview
+}
+
+func (s *Scraper) meta(key string) string {
+	content, _ := metaContent(s.doc, key)
+	return content
+}
+
+// metaURL returns the content of the meta tag key resolved relative to the document URL
+func (s *Scraper) metaURL(key string) string {
+	content := s.meta(key)
+	if content == "" {
+		return ""
+	}
+	u, err := s.absoluteURL(content)
+	if err != nil {
+		return ""
+	}
+
+	return u
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
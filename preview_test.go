@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// countingHTTPClient counts requests passed to client
+type countingHTTPClient struct {
+	client   HTTPClient
+	requests int
+}
+
+func (c *countingHTTPClient) Get(u *url.URL) (*http.Response, error) {
+	c.requests++
+	return c.client.Get(u)
+}
+
+func TestPreviewer(t *testing.T) {
+	client := &countingHTTPClient{client: httpClientWithPages{
+		"https://shop.test/item": `<html><head>
+<title>Item | Shop</title>
+<meta name="description" content="Plain description">
+<meta property="og:title" content="Item">
+<meta property="og:image" content="/img/item.jpg">
+<meta property="og:site_name" content="Shop">
+<link rel="canonical" href="/item">
+<link rel="icon" href="/favicon.png" sizes="32x32">
+</head></html>`,
+	}}
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	p, err := NewPreviewer(client, time.Minute, ClockFunc(func() time.Time { return now }))
+	if err != nil {
+		t.Fatalf("NewPreviewer() error = %v", err)
+	}
+
+	want := LinkPreview{
+		URL:         "https://shop.test/item",
+		Title:       "Item",
+		Description: "Plain description",
+		Image:       "https://shop.test/img/item.jpg",
+		SiteName:    "Shop",
+		Favicon:     "https://shop.test/favicon.png",
+	}
+	for i := 0; i < 2; i++ {
+		got, err := p.Preview("https://shop.test/item")
+		if err != nil {
+			t.Fatalf("Preview() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("Preview() got = %+v, want %+v", got, want)
+		}
+	}
+	if client.requests != 1 {
+		t.Errorf("Preview() made %d requests, want 1 for a cached preview", client.requests)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err = p.Preview("https://shop.test/item"); err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if client.requests != 2 {
+		t.Errorf("Preview() made %d requests, want 2 after the preview expired", client.requests)
+	}
+
+	if _, err = p.Preview("https://shop.test/missing"); err == nil {
+		t.Errorf("Preview() expected error for a missing page")
+	}
+}
+
+func TestScraperPreview(t *testing.T) {
+	s, err := New("https://someAddress", &httpClientWithoutError{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got := s.Preview(nil)
+	if got.Title == "" || got.Image == "" {
+		t.Errorf("Preview() got = %+v, want title and image", got)
+	}
+	if got.URL != "https://hotline.ua/computer-videokarty/gigabyte-geforce-gtx-1060-g1-gaming-6g-gv-n1060g1-gaming-6gd" {
+		t.Errorf("Preview() URL got = %v, want og:url", got.URL)
+	}
+	if got.Favicon != "" {
+		t.Errorf("Preview() resolved favicon without client")
+	}
+}
@@ -0,0 +1,278 @@
+package scraper
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ErrProductNotFound is returned by Product when the page has neither product name nor price
+var ErrProductNotFound = errors.New("product not found")
+
+// Confidence of fields depending on the source they were extracted from
+const (
+	confidenceJSONLD    = 0.95
+	confidenceMicrodata = 0.9
+	confidenceOpenGraph = 0.8
+	confidenceMarkup    = 0.5
+)
+
+type (
+	// Product is a product description extracted from a page without site specific selectors.
+	// Availability is a schema.org ItemAvailability name like "InStock", empty if unknown.
+	Product struct {
+		Name         string
+		Price        Money
+		Availability string
+		Image        string
+		Confidence   ProductConfidence
+	}
+
+	// ProductConfidence holds confidence scores from 0 to 1 of Product fields, 0 for empty fields.
+	// Structured data scores higher than OpenGraph tags, guesses from page markup score lowest.
+	ProductConfidence struct {
+		Name         float64
+		Price        float64
+		Availability float64
+		Image        float64
+	}
+)
+
+// Product extracts a product combining JSON-LD Product, microdata, OpenGraph product tags and
+// common price markup. Sources are tried in this order, each filling only fields still empty.
+func (s *Scraper) Product() (Product, error) {
+	var p Product
+	for _, extract := range []func(*html.Node, *Product){
+		productFromJSONLD,
+		productFromMicrodata,
+		productFromOpenGraph,
+		productFromMarkup,
+	} {
+		extract(s.doc, &p)
+		if p.complete() {
+			break
+		}
+	}
+
+	if p.Name == "" && p.Price == (Money{}) {
+		return Product{}, ErrProductNotFound
+	}
+
+	return p, nil
+}
+
+func (p *Product) complete() bool {
+	return p.Name != "" && p.Price.Amount != 0 && p.Price.Currency != "" && p.Availability != "" && p.Image != ""
+}
+
+// WithMinConfidence returns p with fields scored below minConfidence dropped
+func (p Product) WithMinConfidence(minConfidence float64) Product {
+	if p.Confidence.Name < minConfidence {
+		p.Name, p.Confidence.Name = "", 0
+	}
+	if p.Confidence.Price < minConfidence {
+		p.Price, p.Confidence.Price = Money{}, 0
+	}
+	if p.Confidence.Availability < minConfidence {
+		p.Availability, p.Confidence.Availability = "", 0
+	}
+	if p.Confidence.Image < minConfidence {
+		p.Image, p.Confidence.Image = "", 0
+	}
+
+	return p
+}
+
+// LowConfidence returns names of non-empty fields scored below minConfidence, to flag them instead of dropping
+func (p Product) LowConfidence(minConfidence float64) []string {
+	var fields []string
+	for _, f := range []struct {
+		name       string
+		set        bool
+		confidence float64
+	}{
+		{name: "name", set: p.Name != "", confidence: p.Confidence.Name},
+		{name: "price", set: p.Price != Money{}, confidence: p.Confidence.Price},
+		{name: "availability", set: p.Availability != "", confidence: p.Confidence.Availability},
+		{name: "image", set: p.Image != "", confidence: p.Confidence.Image},
+	} {
+		if f.set && f.confidence < minConfidence {
+			fields = append(fields, f.name)
+		}
+	}
+
+	return fields
+}
+
+func (p *Product) setName(name string, confidence float64) {
+	if name = strings.TrimSpace(name); p.Name == "" && name != "" {
+		p.Name, p.Confidence.Name = name, confidence
+	}
+}
+
+func (p *Product) setPrice(amount, currency string, confidence float64) {
+	if p.Price.Amount != 0 {
+		if p.Price.Currency == "" {
+			p.Price.Currency = strings.TrimSpace(currency)
+		}
+		return
+	}
+	price, err := ParsePrice(amount)
+	if err != nil || price.Amount == 0 {
+		return
+	}
+	if c := strings.TrimSpace(currency); c != "" {
+		price.Currency = c
+	}
+	p.Price, p.Confidence.Price = price, confidence
+}
+
+func (p *Product) setAvailability(availability string, confidence float64) {
+	if availability = strings.TrimSpace(availability); p.Availability == "" && availability != "" {
+		p.Availability = availability[strings.LastIndexByte(availability, '/')+1:]
+		p.Confidence.Availability = confidence
+	}
+}
+
+func (p *Product) setImage(image string, confidence float64) {
+	if image = strings.TrimSpace(image); p.Image == "" && image != "" {
+		p.Image, p.Confidence.Image = image, confidence
+	}
+}
+
+func productFromJSONLD(root *html.Node, p *Product) {
+	for _, v := range jsonLD(root) {
+		for _, o := range jsonLDObjects(v) {
+			if !slices.Contains(jsonLDTypes(o), "Product") {
+				continue
+			}
+			p.setName(jsonString(o["name"]), confidenceJSONLD)
+			p.setImage(jsonLDImage(o["image"]), confidenceJSONLD)
+			for _, offer := range jsonLDObjects(o["offers"]) {
+				amount := jsonString(offer["price"])
+				if amount == "" {
+					amount = jsonString(offer["lowPrice"])
+				}
+				p.setPrice(amount, jsonString(offer["priceCurrency"]), confidenceJSONLD)
+				p.setAvailability(jsonString(offer["availability"]), confidenceJSONLD)
+			}
+		}
+	}
+}
+
+func productFromOpenGraph(root *html.Node, p *Product) {
+	if t, _ := metaContent(root, "og:type"); t != "" && !strings.Contains(strings.ToLower(t), "product") {
+		return
+	}
+	name, _ := metaContent(root, "og:title")
+	p.setName(name, confidenceOpenGraph)
+	for _, prefix := range []string{"product:price", "og:price"} {
+		amount, _ := metaContent(root, prefix+":amount")
+		currency, _ := metaContent(root, prefix+":currency")
+		p.setPrice(amount, currency, confidenceOpenGraph)
+	}
+	availability, _ := metaContent(root, "product:availability")
+	if availability == "" {
+		availability, _ = metaContent(root, "og:availability")
+	}
+	p.setAvailability(availability, confidenceOpenGraph)
+	image, _ := metaContent(root, "og:image")
+	p.setImage(image, confidenceOpenGraph)
+}
+
+func productFromMicrodata(root *html.Node, p *Product) {
+	walk(root, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		itemType, _ := attr(n, "itemtype")
+		if !strings.HasSuffix(strings.TrimSpace(itemType), "/Product") {
+			return true
+		}
+
+		var amount, currency string
+		walk(n, func(c *html.Node) bool {
+			if c.Type != html.ElementNode {
+				return true
+			}
+			switch prop, _ := attr(c, "itemprop"); prop {
+			case "name":
+				p.setName(microdataValue(c), confidenceMicrodata)
+			case "price", "lowPrice":
+				if amount == "" {
+					amount = microdataValue(c)
+				}
+			case "priceCurrency":
+				currency = microdataValue(c)
+			case "availability":
+				p.setAvailability(microdataValue(c), confidenceMicrodata)
+			case "image":
+				p.setImage(microdataValue(c), confidenceMicrodata)
+			}
+			return true
+		})
+		p.setPrice(amount, currency, confidenceMicrodata)
+
+		return false
+	})
+}
+
+// productFromMarkup falls back to the first h1 as a name and to elements whose class or id mentions a price
+func productFromMarkup(root *html.Node, p *Product) {
+	if h1 := elements(root, "h1"); len(h1) != 0 {
+		p.setName(textContent(h1[0]), confidenceMarkup)
+	}
+	walk(root, func(n *html.Node) bool {
+		if p.Price.Amount != 0 {
+			return false
+		}
+		if n.Type != html.ElementNode || n.Data == "script" || n.Data == "style" {
+			return true
+		}
+		class, _ := attr(n, "class")
+		id, _ := attr(n, "id")
+		if strings.Contains(strings.ToLower(class+" "+id), "price") {
+			text := textContent(n)
+			p.setPrice(text, detectCurrency(text), confidenceMarkup)
+		}
+		return true
+	})
+}
+
+func microdataValue(n *html.Node) string {
+	for _, key := range []string{"content", "href", "src"} {
+		if v, ok := attr(n, key); ok {
+			return v
+		}
+	}
+
+	return textContent(n)
+}
+
+func jsonLDImage(v any) string {
+	switch t := v.(type) {
+	case []any:
+		if len(t) != 0 {
+			return jsonLDImage(t[0])
+		}
+	case map[string]any:
+		return jsonString(t["url"])
+	}
+
+	return jsonString(v)
+}
+
+// jsonString returns JSON strings and numbers as strings, empty string otherwise
+func jsonString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,111 @@
+package scraper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestScraperProduct(t *testing.T) {
+	r, _ := New("https://someAddress", &httpClientWithoutError{})
+
+	tests := []struct {
+		name    string
+		s       *Scraper
+		want    Product
+		wantErr error
+	}{
+		{
+			name: "json-ld",
+			s:    r,
+			want: Product{
+				Name:         "GeForce GTX 1060 G1 Gaming 6G (GV-N1060G1 GAMING-6GD)",
+				Price:        Money{Amount: 12981, Currency: "UAH"},
+				Availability: "InStock",
+				Image:        "https://hotline.ua/img/tx/999/999855295.jpg",
+				Confidence:   ProductConfidence{Name: 0.95, Price: 0.95, Availability: 0.95, Image: 0.95},
+			},
+		},
+		{
+			name: "open graph",
+			s: newTestScraper(t, `<html><head>
+<meta property="og:type" content="product">
+<meta property="og:title" content="Phone">
+<meta property="product:price:amount" content="199.90">
+<meta property="product:price:currency" content="USD">
+<meta property="og:image" content="https://shop/phone.jpg">
+</head></html>`),
+			want: Product{
+				Name:       "Phone",
+				Price:      Money{Amount: 199.9, Currency: "USD"},
+				Image:      "https://shop/phone.jpg",
+				Confidence: ProductConfidence{Name: 0.8, Price: 0.8, Image: 0.8},
+			},
+		},
+		{
+			name: "microdata",
+			s: newTestScraper(t, `<div itemscope itemtype="https://schema.org/Product">
+<span itemprop="name">Kettle</span>
+<img itemprop="image" src="/kettle.png">
+<div itemprop="offers" itemscope itemtype="https://schema.org/Offer">
+<span itemprop="price" content="25.00">25,00 €</span>
+<meta itemprop="priceCurrency" content="EUR">
+<link itemprop="availability" href="https://schema.org/OutOfStock">
+</div></div>`),
+			want: Product{
+				Name:         "Kettle",
+				Price:        Money{Amount: 25, Currency: "EUR"},
+				Availability: "OutOfStock",
+				Image:        "/kettle.png",
+				Confidence:   ProductConfidence{Name: 0.9, Price: 0.9, Availability: 0.9, Image: 0.9},
+			},
+		},
+		{
+			name: "price markup",
+			s:    newTestScraper(t, `<h1> Lamp </h1><div class="product-price">1 250 грн</div>`),
+			want: Product{
+				Name:       "Lamp",
+				Price:      Money{Amount: 1250, Currency: "UAH"},
+				Confidence: ProductConfidence{Name: 0.5, Price: 0.5},
+			},
+		},
+		{
+			name:    "not a product",
+			s:       newTestScraper(t, `<p>nothing here</p>`),
+			wantErr: ErrProductNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.Product()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Product() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Product() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductMinConfidence(t *testing.T) {
+	p := Product{
+		Name:       "Lamp",
+		Price:      Money{Amount: 1250, Currency: "UAH"},
+		Image:      "/lamp.png",
+		Confidence: ProductConfidence{Name: 0.5, Price: 0.9, Image: 0.8},
+	}
+
+	if got, want := p.LowConfidence(0.8), []string{"name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LowConfidence() got = %v, want %v", got, want)
+	}
+
+	want := Product{
+		Price:      Money{Amount: 1250, Currency: "UAH"},
+		Confidence: ProductConfidence{Price: 0.9},
+	}
+	if got := p.WithMinConfidence(0.85); got != want {
+		t.Errorf("WithMinConfidence() got = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RangeHTTPClient is a HTTPClient able to request only the first bytes of a page
+type RangeHTTPClient interface {
+	HTTPClient
+	// GetRange requests the first limit bytes of the page, the server may ignore it and respond with the whole page
+	GetRange(u *url.URL, limit int64) (*http.Response, error)
+}
+
+// WithByteRange makes New download and parse only the first limit bytes of the page, which is enough for
+// extraction from <head>. Range requests are used if the client is a RangeHTTPClient, otherwise or if the server
+// ignores the range the body is read up to the limit and the connection is dropped. The Scraper reports Truncated
+// if the page was cut.
+func WithByteRange(limit int64) Option {
+	return func(o *options) error {
+		if limit <= 0 {
+			return errors.New("byte range limit should be positive")
+		}
+		o.byteRange = limit
+		return nil
+	}
+}
+
+// GetRange performs GET request with a Range header for the first limit bytes
+func (c *httpClientWithRetry) GetRange(url *url.URL, limit int64) (*http.Response, error) {
+	if url == nil {
+		return nil, errors.New("url cannot be nil")
+	}
+	if limit <= 0 {
+		return nil, errors.New("limit should be positive")
+	}
+
+	req := c.newRequest(url)
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", limit-1))
+	resp, err := c.do(req)
+
+	return resp, requestIDError(req, err)
+}
+
+// get requests the first byteRange bytes if byteRange is set and the client supports ranges, the whole page otherwise
+func get(client HTTPClient, u *url.URL, byteRange int64) (*http.Response, error) {
+	if rc, ok := client.(RangeHTTPClient); ok && byteRange > 0 {
+		return rc.GetRange(u, byteRange)
+	}
+
+	return client.Get(u)
+}
+
+// rangeTruncated reports whether resp has more bytes than were read up to the range limit
+func rangeTruncated(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusPartialContent {
+		// Content-Range: bytes 0-1023/146515, the total is * if unknown
+		contentRange := resp.Header.Get("Content-Range")
+		slash := strings.LastIndexByte(contentRange, '/')
+		dash := strings.LastIndexByte(contentRange, '-')
+		if slash != -1 && dash != -1 && dash < slash {
+			end, endErr := strconv.ParseInt(contentRange[dash+1:slash], 10, 64)
+			total, totalErr := strconv.ParseInt(contentRange[slash+1:], 10, 64)
+			if endErr == nil && totalErr == nil {
+				return end+1 < total
+			}
+		}
+	}
+
+	var b [1]byte
+	n, _ := io.ReadFull(resp.Body, b[:])
+	return n != 0
+}
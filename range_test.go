@@ -0,0 +1,94 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithByteRange(t *testing.T) {
+	page, err := os.ReadFile("./test-data/correct.html.txt")
+	if err != nil {
+		t.Fatalf("read test page: %v", err)
+	}
+
+	var gotRange string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRange = r.Header.Get("Range")
+		if strings.HasSuffix(r.URL.Path, "/no-range") {
+			r.Header.Del("Range")
+		}
+		http.ServeContent(w, r, "page.html", time.Time{}, strings.NewReader(string(page)))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClientWithRetry(0, 0)
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		client        HTTPClient
+		url           string
+		limit         int64
+		wantRange     string
+		wantTruncated bool
+	}{
+		{
+			name:          "range supported",
+			client:        client,
+			url:           server.URL + "/page",
+			limit:         4096,
+			wantRange:     "bytes=0-4095",
+			wantTruncated: true,
+		},
+		{
+			name:          "range ignored by server",
+			client:        client,
+			url:           server.URL + "/no-range",
+			limit:         4096,
+			wantRange:     "bytes=0-4095",
+			wantTruncated: true,
+		},
+		{
+			name:          "client without range support",
+			client:        &httpClientWithoutError{},
+			url:           "https://someAddress",
+			limit:         4096,
+			wantTruncated: true,
+		},
+		{
+			name:      "limit above page size",
+			client:    client,
+			url:       server.URL + "/page",
+			limit:     int64(len(page)) * 2,
+			wantRange: "bytes=0-",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRange = ""
+			s, err := New(tt.url, tt.client, WithByteRange(tt.limit))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if tt.wantRange != "" && !strings.HasPrefix(gotRange, tt.wantRange) {
+				t.Errorf("Range header got = %v, want %v", gotRange, tt.wantRange)
+			}
+			if s.Truncated() != tt.wantTruncated {
+				t.Errorf("Truncated() got = %v, want %v", s.Truncated(), tt.wantTruncated)
+			}
+			if title := s.SEOAudit().Title; title == "" {
+				t.Errorf("SEOAudit() did not find the title in the first %d bytes", tt.limit)
+			}
+		})
+	}
+
+	if _, err = New(server.URL, client, WithByteRange(0)); err == nil {
+		t.Errorf("New() expected error for zero limit")
+	}
+}
@@ -0,0 +1,201 @@
+package scraper
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ecbDailyRatesURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+	ecbRatesTTL      = 6 * time.Hour
+	// ecbRetryAfter is how long after a failed refresh the rates are not fetched again
+	ecbRetryAfter = 5 * time.Minute
+)
+
+// ErrUnknownRate is returned when a RateProvider has no rate for a currency
+var ErrUnknownRate = errors.New("unknown exchange rate")
+
+type (
+	// RateProvider returns how many units of currency to one unit of currency from is worth
+	RateProvider interface {
+		Rate(from, to string) (float64, error)
+	}
+
+	// FixedRates is a RateProvider backed by a static table of rates against a base currency
+	FixedRates struct {
+		base  string
+		rates map[string]float64
+	}
+
+	// ECBRates is a RateProvider backed by the daily euro reference rates of the European Central Bank.
+	// Rates are fetched lazily through the given client and refreshed every 6 hours. A failed refresh is retried
+	// after 5 minutes at the earliest, meanwhile the stale rates or the error of the refresh are returned.
+	ECBRates struct {
+		client HTTPClient
+		url    *url.URL
+		clock  Clock
+
+		mu      sync.Mutex
+		rates   *FixedRates
+		fetched time.Time
+		// failed is the time of the last failed refresh and err its error, failed is zero after a successful one
+		failed time.Time
+		err    error
+	}
+
+	ecbEnvelope struct {
+		Rates []struct {
+			Currency string  `xml:"currency,attr"`
+			Rate     float64 `xml:"rate,attr"`
+		} `xml:"Cube>Cube>Cube"`
+	}
+)
+
+// ConvertTo converts m to currency using rates. Amounts without currency can't be converted.
+func (m Money) ConvertTo(currency string, rates RateProvider) (Money, error) {
+	if rates == nil {
+		return Money{}, errors.New("rates should be not nil")
+	}
+	if m.Currency == "" {
+		return Money{}, errors.New("money has no currency")
+	}
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if strings.EqualFold(m.Currency, currency) {
+		return Money{Amount: m.Amount, Currency: currency}, nil
+	}
+
+	rate, err := rates.Rate(m.Currency, currency)
+	if err != nil {
+		return Money{}, fmt.Errorf("get rate %s/%s: %w", m.Currency, currency, err)
+	}
+
+	return Money{Amount: m.Amount * rate, Currency: currency}, nil
+}
+
+// NewFixedRates creates a RateProvider from rates, where rates[c] is how many units of c one unit of base is worth
+func NewFixedRates(base string, rates map[string]float64) (*FixedRates, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	if base == "" {
+		return nil, errors.New("base currency should be not empty")
+	}
+
+	normalized := make(map[string]float64, len(rates)+1)
+	for c, r := range rates {
+		if r <= 0 {
+			return nil, fmt.Errorf("rate of currency [%s] should be positive", c)
+		}
+		normalized[strings.ToUpper(strings.TrimSpace(c))] = r
+	}
+	normalized[base] = 1
+
+	return &FixedRates{base: base, rates: normalized}, nil
+}
+
+func (r *FixedRates) Rate(from, to string) (float64, error) {
+	fromRate, ok := r.rates[strings.ToUpper(from)]
+	if !ok {
+		return 0, fmt.Errorf("currency [%s]: %w", from, ErrUnknownRate)
+	}
+	toRate, ok := r.rates[strings.ToUpper(to)]
+	if !ok {
+		return 0, fmt.Errorf("currency [%s]: %w", to, ErrUnknownRate)
+	}
+
+	return toRate / fromRate, nil
+}
+
+// NewECBRates returns ECB rates fetched through client, clock tells when they are stale and is SystemClock if nil
+func NewECBRates(client HTTPClient, clock Clock) (*ECBRates, error) {
+	if client == nil {
+		return nil, errors.New("client should be not nil")
+	}
+
+	u, err := url.Parse(ecbDailyRatesURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse url [%s]: %w", ecbDailyRatesURL, err)
+	}
+
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &ECBRates{client: client, url: u, clock: clock}, nil
+}
+
+func (r *ECBRates) Rate(from, to string) (float64, error) {
+	rates, err := r.current()
+	if err != nil {
+		return 0, err
+	}
+
+	return rates.Rate(from, to)
+}
+
+func (r *ECBRates) current() (*FixedRates, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock.Now()
+	if r.rates != nil && now.Sub(r.fetched) < ecbRatesTTL {
+		return r.rates, nil
+	}
+	// callers hold the lock while rates are fetched, so an endpoint which is down isn't waited for on every rate
+	if !r.failed.IsZero() && now.Sub(r.failed) < ecbRetryAfter {
+		if r.rates != nil {
+			return r.rates, nil
+		}
+		return nil, r.err
+	}
+
+	rates, err := r.fetch()
+	if err != nil {
+		r.failed, r.err = now, err
+		if r.rates != nil {
+			log.Printf("refresh ECB rates error: %s. Using rates fetched at %s", err.Error(), r.fetched)
+			return r.rates, nil
+		}
+		return nil, err
+	}
+	r.rates, r.fetched, r.failed, r.err = rates, now, time.Time{}, nil
+
+	return rates, nil
+}
+
+func (r *ECBRates) fetch() (*FixedRates, error) {
+	resp, err := r.client.Get(r.url)
+	if err != nil {
+		return nil, fmt.Errorf("perform GET request to url [%s]: %w", r.url, err)
+	}
+	defer func() {
+		if resp == nil || resp.Body == nil {
+			return
+		}
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Printf("resp body close error: %s", closeErr.Error())
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code is not 200: %d", resp.StatusCode)
+	}
+
+	var envelope ecbEnvelope
+	if err = xml.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("decode ECB rates: %w", err)
+	}
+	if len(envelope.Rates) == 0 {
+		return nil, errors.New("ECB rates are empty")
+	}
+
+	rates := make(map[string]float64, len(envelope.Rates))
+	for _, rate := range envelope.Rates {
+		rates[rate.Currency] = rate.Rate
+	}
+
+	return NewFixedRates("EUR", rates)
+}
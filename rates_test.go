@@ -0,0 +1,139 @@
+package scraper
+
+import (
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type httpClientWithECBRates struct {
+	calls int
+	// err is returned instead of rates if set
+	err error
+}
+
+func (c *httpClientWithECBRates) Get(_ *url.URL) (*http.Response, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body: io.NopCloser(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2024-03-01">
+			<Cube currency="USD" rate="1.08"/>
+			<Cube currency="PLN" rate="4.32"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`)),
+	}, nil
+}
+
+func TestMoneyConvertTo(t *testing.T) {
+	fixed, err := NewFixedRates("usd", map[string]float64{"UAH": 40, "EUR": 0.5})
+	if err != nil {
+		t.Fatalf("NewFixedRates() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		money    Money
+		currency string
+		want     Money
+		wantErr  error
+	}{
+		{name: "from base", money: Money{Amount: 2, Currency: "USD"}, currency: "UAH", want: Money{Amount: 80, Currency: "UAH"}},
+		{name: "cross rate", money: Money{Amount: 400, Currency: "UAH"}, currency: "eur", want: Money{Amount: 5, Currency: "EUR"}},
+		{name: "same currency", money: Money{Amount: 3, Currency: "UAH"}, currency: "UAH", want: Money{Amount: 3, Currency: "UAH"}},
+		{name: "unknown currency", money: Money{Amount: 3, Currency: "GBP"}, currency: "UAH", wantErr: ErrUnknownRate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.money.ConvertTo(tt.currency, fixed)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ConvertTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Currency != tt.want.Currency || math.Abs(got.Amount-tt.want.Amount) > 1e-9 {
+				t.Errorf("ConvertTo() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err = (Money{Amount: 1}).ConvertTo("USD", fixed); err == nil {
+		t.Errorf("ConvertTo() without currency expected error")
+	}
+}
+
+func TestECBRates(t *testing.T) {
+	client := &httpClientWithECBRates{}
+	rates, err := NewECBRates(client, nil)
+	if err != nil {
+		t.Fatalf("NewECBRates() error = %v", err)
+	}
+
+	got, err := (Money{Amount: 10, Currency: "EUR"}).ConvertTo("USD", rates)
+	if err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+	if math.Abs(got.Amount-10.8) > 1e-9 {
+		t.Errorf("ConvertTo() got = %v, want %v", got.Amount, 10.8)
+	}
+
+	got, err = (Money{Amount: 4.32, Currency: "PLN"}).ConvertTo("USD", rates)
+	if err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+	if math.Abs(got.Amount-1.08) > 1e-9 {
+		t.Errorf("ConvertTo() got = %v, want %v", got.Amount, 1.08)
+	}
+	if client.calls != 1 {
+		t.Errorf("rates fetched %d times, want %d", client.calls, 1)
+	}
+}
+
+func TestECBRatesRefreshBackoff(t *testing.T) {
+	client := &httpClientWithECBRates{err: errors.New("ECB is down")}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	rates, err := NewECBRates(client, ClockFunc(func() time.Time { return now }))
+	if err != nil {
+		t.Fatalf("NewECBRates() error = %v", err)
+	}
+
+	steps := []struct {
+		name      string
+		advance   time.Duration
+		down      bool
+		wantErr   bool
+		wantCalls int
+	}{
+		{name: "first fetch fails", down: true, wantErr: true, wantCalls: 1},
+		{name: "error is cached", advance: time.Minute, down: true, wantErr: true, wantCalls: 1},
+		{name: "retried after backoff", advance: ecbRetryAfter, wantCalls: 2},
+		{name: "refresh fails with stale rates", advance: ecbRatesTTL, down: true, wantCalls: 3},
+		{name: "stale rates are used during backoff", advance: time.Minute, down: true, wantCalls: 3},
+		{name: "refresh retried after backoff", advance: ecbRetryAfter, down: true, wantCalls: 4},
+	}
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		client.err = nil
+		if step.down {
+			client.err = errors.New("ECB is down")
+		}
+		_, err = rates.Rate("EUR", "USD")
+		if (err != nil) != step.wantErr {
+			t.Errorf("%s: Rate() error = %v, wantErr %v", step.name, err, step.wantErr)
+		}
+		if client.calls != step.wantCalls {
+			t.Errorf("%s: rates fetched %d times, want %d", step.name, client.calls, step.wantCalls)
+		}
+	}
+}
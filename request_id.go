@@ -0,0 +1,94 @@
+package scraper
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// DefaultRequestIDHeader is the header commonly used to pass request IDs
+const DefaultRequestIDHeader = "X-Request-ID"
+
+type (
+	// RequestIDError is returned by clients created WithRequestID when a fetch fails, so the ID of the fetch
+	// is known even without a response. Its message is the one of Err.
+	RequestIDError struct {
+		RequestID string
+		Err       error
+	}
+
+	// requestIDKey is the context key of the request ID of a request made by the retry client
+	requestIDKey struct{}
+)
+
+func (e *RequestIDError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RequestIDError) Unwrap() error {
+	return e.Err
+}
+
+// WithRequestID makes the client generate an ID for every fetch, send it in the header and add it to its log lines,
+// attempts recorded by WithRetryRecorder and records of NewAuditHTTPClient, so they can be correlated with logs
+// of the target site. All attempts of a fetch share the ID, and a failed fetch returns *RequestIDError naming it.
+// IDs are random if generate is nil, a deterministic generate makes runs reproducible. Authenticators may read
+// the ID of the request with RequestIDFromContext.
+func WithRequestID(header string, generate func() string) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if strings.TrimSpace(header) == "" {
+			return errors.New("request id header should be not empty")
+		}
+		if generate == nil {
+			generate = randomRequestID
+		}
+		c.requestIDHeader, c.requestID = http.CanonicalHeaderKey(header), generate
+		return nil
+	}
+}
+
+// RequestIDFromContext returns the ID of the request made with ctx by a client created WithRequestID
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// setRequestID sets a new ID of the fetch to req, the request is returned as is if the client has no IDs
+func (c *httpClientWithRetry) setRequestID(req *http.Request) *http.Request {
+	if c.requestID == nil {
+		return req
+	}
+
+	id := c.requestID()
+	req.Header.Set(c.requestIDHeader, id)
+	return req.WithContext(context.WithValue(req.Context(), requestIDKey{}, id))
+}
+
+// requestIDError returns err of the fetch of req as *RequestIDError if the request has an ID
+func requestIDError(req *http.Request, err error) error {
+	if err == nil {
+		return nil
+	}
+	if id, ok := RequestIDFromContext(req.Context()); ok {
+		return &RequestIDError{RequestID: id, Err: err}
+	}
+	return err
+}
+
+// requestLogPrefix returns the prefix of log lines about req naming its ID, empty if it has none
+func requestLogPrefix(req *http.Request) string {
+	if id, ok := RequestIDFromContext(req.Context()); ok {
+		return "request " + id + ": "
+	}
+	return ""
+}
+
+func randomRequestID() string {
+	var b [16]byte
+	// crypto/rand.Read doesn't fail on supported platforms
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,144 @@
+package scraper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestWithRequestID(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("X-Correlation-Id"))
+		_, _ = w.Write([]byte("<p>ok</p>"))
+	}))
+	defer server.Close()
+
+	var (
+		n           int
+		fromContext []string
+	)
+	recorder := NewRetryRecorder()
+	client, err := NewHTTPClientWithRetry(1, 0,
+		WithRequestID("x-correlation-id", func() string {
+			n++
+			return fmt.Sprintf("fetch-%d", n)
+		}),
+		WithRetryRecorder(recorder),
+		WithAuth("*", AuthFunc(func(req *http.Request) error {
+			id, _ := RequestIDFromContext(req.Context())
+			fromContext = append(fromContext, id)
+			return nil
+		})),
+	)
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+
+	// the first attempt of the first fetch fails, so both of its attempts must share the ID
+	retry := client.(*httpClientWithRetry)
+	transport := retry.client.Transport
+	var failed bool
+	retry.client = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if !failed {
+			failed = true
+			return nil, &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+		}
+		return transport.RoundTrip(req)
+	})}
+
+	var audit bytes.Buffer
+	audited, err := NewAuditHTTPClient(client, &audit)
+	if err != nil {
+		t.Fatalf("NewAuditHTTPClient() error = %v", err)
+	}
+	target, _ := url.Parse(server.URL)
+	for i := 0; i < 2; i++ {
+		resp, err := audited.Get(target)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	if want := []string{"fetch-1", "fetch-2"}; fmt.Sprint(received) != fmt.Sprint(want) {
+		t.Errorf("received request ids = %v, want %v", received, want)
+	}
+	if want := []string{"fetch-1", "fetch-1", "fetch-2"}; fmt.Sprint(fromContext) != fmt.Sprint(want) {
+		t.Errorf("request ids from context = %v, want %v", fromContext, want)
+	}
+	var attempts []string
+	for _, a := range recorder.Attempts() {
+		attempts = append(attempts, a.RequestID)
+	}
+	if want := []string{"fetch-1", "fetch-1", "fetch-2"}; fmt.Sprint(attempts) != fmt.Sprint(want) {
+		t.Errorf("recorded attempt request ids = %v, want %v", attempts, want)
+	}
+	dec := json.NewDecoder(&audit)
+	for _, want := range []string{"fetch-1", "fetch-2"} {
+		var record AuditRecord
+		if err = dec.Decode(&record); err != nil {
+			t.Fatalf("decode audit record: %v", err)
+		}
+		if record.RequestID != want {
+			t.Errorf("audit record request id = %q, want %q", record.RequestID, want)
+		}
+	}
+}
+
+func TestWithRequestIDFailedFetch(t *testing.T) {
+	client, err := NewHTTPClientWithRetry(0, 0, WithRequestID(DefaultRequestIDHeader, func() string { return "fetch-1" }))
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+	client.(*httpClientWithRetry).client = &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	})}
+
+	var audit bytes.Buffer
+	audited, err := NewAuditHTTPClient(client, &audit)
+	if err != nil {
+		t.Fatalf("NewAuditHTTPClient() error = %v", err)
+	}
+	target, _ := url.Parse("https://someAddress")
+	_, err = audited.Get(target)
+	var idErr *RequestIDError
+	if !errors.As(err, &idErr) || idErr.RequestID != "fetch-1" {
+		t.Fatalf("Get() error = %v, want *RequestIDError of fetch-1", err)
+	}
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		t.Errorf("Get() error = %v, want it to wrap *net.OpError", err)
+	}
+
+	var record AuditRecord
+	if err = json.NewDecoder(&audit).Decode(&record); err != nil {
+		t.Fatalf("decode audit record: %v", err)
+	}
+	if record.RequestID != "fetch-1" || record.Error == "" {
+		t.Errorf("audit record got = %+v, want request id fetch-1 and an error", record)
+	}
+}
+
+func TestWithRequestIDInvalid(t *testing.T) {
+	for _, opt := range []ClientOption{
+		WithRequestID(" ", nil),
+		WithHostOptions("*.test", WithRequestID(DefaultRequestIDHeader, nil)),
+	} {
+		if _, err := NewHTTPClientWithRetry(0, 0, opt); err == nil {
+			t.Errorf("NewHTTPClientWithRetry() expected error")
+		}
+	}
+
+	if a, b := randomRequestID(), randomRequestID(); len(a) != 32 || a == b {
+		t.Errorf("randomRequestID() got %q and %q", a, b)
+	}
+}
@@ -0,0 +1,127 @@
+package scraper
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redactedHeaders are headers carrying credentials, their values are replaced with xxxxx in recorded attempts
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", HeaderSignature}
+
+type (
+	// RetryAttempt describes a single attempt of a request made by the retry client
+	RetryAttempt struct {
+		URL string
+		// RequestID is the ID of the fetch set by WithRequestID, shared by all its attempts
+		RequestID string
+		Attempt   uint
+		Time      time.Time
+		// StatusCode is set if the attempt got a response, Err otherwise
+		StatusCode int
+		Err        *TransportError
+		// Retry and Backoff are the decision of the retry policy taken after a failed attempt
+		Retry     bool
+		Backoff   time.Duration
+		Proxy     string
+		UserAgent string
+		// Header is the header of the request with values of credential headers and WithRedactedHeaders redacted
+		Header http.Header
+		// Profile is the name of the geo profile of the client or of the host scope, empty if there is none
+		Profile string
+	}
+
+	// RetryRecorder collects attempts of requests made by clients created WithRetryRecorder, safe for concurrent use
+	RetryRecorder struct {
+		mu       sync.Mutex
+		attempts []RetryAttempt
+	}
+)
+
+func NewRetryRecorder() *RetryRecorder {
+	return &RetryRecorder{}
+}
+
+// Attempts returns recorded attempts in the order they were made
+func (r *RetryRecorder) Attempts() []RetryAttempt {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts := make([]RetryAttempt, len(r.attempts))
+	copy(attempts, r.attempts)
+
+	return attempts
+}
+
+func (r *RetryRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.attempts = nil
+}
+
+func (r *RetryRecorder) add(attempt RetryAttempt) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.attempts = append(r.attempts, attempt)
+}
+
+func (c *httpClientWithRetry) recordAttempt(req *http.Request, attempt uint, status int, err *TransportError, retry bool, backoff time.Duration) {
+	if c.recorder == nil {
+		return
+	}
+
+	requestID, _ := RequestIDFromContext(req.Context())
+	record := RetryAttempt{
+		URL:        req.URL.String(),
+		RequestID:  requestID,
+		Attempt:    attempt,
+		Time:       c.clock.Now(),
+		StatusCode: status,
+		Err:        err,
+		Retry:      retry,
+		Backoff:    backoff,
+		UserAgent:  req.Header.Get("User-Agent"),
+		Header:     redactHeader(req.Header, c.redactedHeaders),
+		Profile:    c.profileFor(req.URL),
+	}
+	if transport, ok := c.client.Transport.(*http.Transport); ok && transport.Proxy != nil {
+		if proxy, proxyErr := transport.Proxy(req); proxyErr == nil && proxy != nil {
+			record.Proxy = proxy.Redacted()
+		}
+	}
+
+	c.recorder.add(record)
+}
+
+// WithRedactedHeaders adds headers whose values are replaced with xxxxx in attempts recorded WithRetryRecorder,
+// like headers with credentials set by custom authenticators or signers. Authorization, Proxy-Authorization,
+// Cookie and the signature of the HMAC signer are always redacted.
+func WithRedactedHeaders(keys ...string) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		for _, key := range keys {
+			if strings.TrimSpace(key) == "" {
+				return errors.New("redacted header should be not empty")
+			}
+			c.redactedHeaders = append(c.redactedHeaders, http.CanonicalHeaderKey(key))
+		}
+		return nil
+	}
+}
+
+// redactHeader returns a copy of h with values of redactedHeaders and extra headers replaced with xxxxx
+func redactHeader(h http.Header, extra []string) http.Header {
+	h = h.Clone()
+	for _, keys := range [][]string{redactedHeaders, extra} {
+		for _, key := range keys {
+			for i := range h[key] {
+				h[key][i] = "xxxxx"
+			}
+		}
+	}
+
+	return h
+}
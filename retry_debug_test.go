@@ -0,0 +1,138 @@
+package scraper
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRetryRecorder(t *testing.T) {
+	recorder := NewRetryRecorder()
+	client, err := NewHTTPClientWithRetry(2, time.Millisecond, WithRetryRecorder(recorder))
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+
+	var calls int
+	client.(*httpClientWithRetry).client = &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})}
+
+	target, _ := url.Parse("https://someAddress")
+	if _, err = client.Get(target); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	got := recorder.Attempts()
+	if len(got) != 2 {
+		t.Fatalf("Attempts() got %d attempts, want %d", len(got), 2)
+	}
+	if got[0].Attempt != 1 || got[0].Err == nil || got[0].Err.Kind != TransportErrorConnect || !got[0].Retry || got[0].Backoff != time.Millisecond {
+		t.Errorf("first attempt got = %+v", got[0])
+	}
+	if got[1].Attempt != 2 || got[1].Err != nil || got[1].StatusCode != http.StatusOK || got[1].Retry {
+		t.Errorf("second attempt got = %+v", got[1])
+	}
+	if got[0].URL != "https://someAddress" || got[0].Header.Get("Cache-Control") != "no-cache" {
+		t.Errorf("first attempt request details got = %+v", got[0])
+	}
+
+	recorder.Reset()
+	if len(recorder.Attempts()) != 0 {
+		t.Errorf("Reset() did not clear attempts")
+	}
+}
+
+func TestRetryRecorderRedactsCredentials(t *testing.T) {
+	signer, _ := NewHMACSigner("key", []byte("secret"), nil)
+	recorder := NewRetryRecorder()
+	client, err := NewHTTPClientWithRetry(0, 0,
+		WithRetryRecorder(recorder),
+		WithCookies(&http.Cookie{Name: "session", Value: "secret"}),
+		WithAuth("*", AuthFunc(func(req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer secret")
+			req.Header.Set("Proxy-Authorization", "Basic secret")
+			return signer.Authenticate(req)
+		})),
+	)
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+	var sent http.Header
+	client.(*httpClientWithRetry).client = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req.Header
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})}
+
+	target, _ := url.Parse("https://someAddress")
+	if _, err = client.Get(target); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	got := recorder.Attempts()
+	if len(got) != 1 {
+		t.Fatalf("Attempts() got %d attempts, want %d", len(got), 1)
+	}
+	for _, key := range []string{"Authorization", "Proxy-Authorization", "Cookie", HeaderSignature} {
+		if value := got[0].Header.Get(key); value != "xxxxx" {
+			t.Errorf("recorded header %s got = %q, want it redacted", key, value)
+		}
+		if value := sent.Get(key); value == "" || value == "xxxxx" {
+			t.Errorf("sent header %s got = %q, want the credential", key, value)
+		}
+	}
+	if got[0].Header.Get(HeaderSignatureKeyID) != "key" {
+		t.Errorf("recorded header %s got = %q, want %q", HeaderSignatureKeyID, got[0].Header.Get(HeaderSignatureKeyID), "key")
+	}
+}
+
+func TestRetryRecorderRedactsExtraHeaders(t *testing.T) {
+	recorder := NewRetryRecorder()
+	client, err := NewHTTPClientWithRetry(0, 0,
+		WithRetryRecorder(recorder),
+		WithRedactedHeaders("x-api-key"),
+		WithSigner("*", AuthFunc(func(req *http.Request) error {
+			req.Header.Set("X-Api-Key", "secret")
+			req.Header.Set("X-Api-Version", "2")
+			return nil
+		})),
+	)
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+	var sent http.Header
+	client.(*httpClientWithRetry).client = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req.Header
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})}
+
+	target, _ := url.Parse("https://someAddress")
+	if _, err = client.Get(target); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	got := recorder.Attempts()
+	if len(got) != 1 {
+		t.Fatalf("Attempts() got %d attempts, want %d", len(got), 1)
+	}
+	if value := got[0].Header.Get("X-Api-Key"); value != "xxxxx" {
+		t.Errorf("recorded header X-Api-Key got = %q, want it redacted", value)
+	}
+	if value := sent.Get("X-Api-Key"); value != "secret" {
+		t.Errorf("sent header X-Api-Key got = %q, want %q", value, "secret")
+	}
+	if value := got[0].Header.Get("X-Api-Version"); value != "2" {
+		t.Errorf("recorded header X-Api-Version got = %q, want %q", value, "2")
+	}
+
+	if _, err = NewHTTPClientWithRetry(0, 0, WithRedactedHeaders(" ")); err == nil {
+		t.Errorf("NewHTTPClientWithRetry() with an empty redacted header error = nil, want error")
+	}
+}
@@ -1,8 +1,14 @@
 package scraper
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
@@ -34,17 +40,60 @@ type (
 		client       *http.Client
 		retries      uint
 		retryTimeout time.Duration
+		retryPolicy  RetryPolicy
+		dialer       *dialer
+		recorder     *RetryRecorder
+		// redactedHeaders are recorded redacted in addition to the headers redacted by default
+		redactedHeaders []string
+		policies        *DomainPolicies
+		clock           Clock
+		sleeper         Sleeper
+		// requestID generates IDs sent in requestIDHeader, it is nil if requests have no IDs
+		requestID       func() string
+		requestIDHeader string
+		// header is added to every request, proxy overrides the proxy of the transport if set
+		header  http.Header
+		proxy   func(*http.Request) (*url.URL, error)
+		profile string
+		// hostRewrites maps lower case hosts of requested URLs to hosts requested instead
+		hostRewrites map[string]string
+		cookies      []*http.Cookie
+		auth         []hostAuth
+		signers      []hostAuth
+		timeout      time.Duration
+		hosts        []hostConfig
 	}
 
 	Scraper struct {
 		doc *html.Node
+		url *url.URL
+		// profile is the name of the geo profile the document was fetched with
+		profile string
+		frozen  bool
+		cache   *ExtractionCache
+		hash    string
+		limits  TraversalLimits
+
+		diagnostics     *ParseDiagnostics
+		truncated       bool
+		timings         *SelectorTimings
+		caseInsensitive bool
+		// ids indexes elements of the document by id, it is built by Freeze and nil for scrapers whose document
+		// may still be modified through returned nodes
+		ids map[string][]*html.Node
+		// options are the options the document was parsed with, sub-documents of Frames are parsed with them too
+		options options
 	}
 )
 
 // DefaultHTTPClient is a HTTPClient with configured retry: retries = 3, retryTimeout = 30s
 var DefaultHTTPClient = defaultHTTPClientWithRetry()
 
-func New(webAddress string, client HTTPClient) (*Scraper, error) {
+func New(webAddress string, client HTTPClient, opts ...Option) (*Scraper, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("apply option: %w", err)
+	}
 	if !utf8.ValidString(webAddress) {
 		return nil, errors.New("webAddress is not valid utf8 string")
 	}
@@ -61,7 +110,7 @@ func New(webAddress string, client HTTPClient) (*Scraper, error) {
 		return nil, fmt.Errorf("parse url [%s]: %w", webAddress, err)
 	}
 
-	resp, err := client.Get(parsedURL)
+	resp, err := get(client, parsedURL, o.byteRange)
 	if err != nil {
 		return nil, fmt.Errorf("perform GET request to url [%s]: %w", webAddress, err)
 	}
@@ -73,26 +122,148 @@ func New(webAddress string, client HTTPClient) (*Scraper, error) {
 			log.Printf("resp body close error: %s", closeErr.Error())
 		}
 	}()
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK && (o.byteRange == 0 || resp.StatusCode != http.StatusPartialContent) {
 		return nil, fmt.Errorf("status code is not 200: %d", resp.StatusCode)
 	}
 
-	doc, err := html.Parse(resp.Body)
+	body, contentLength := io.Reader(resp.Body), resp.ContentLength
+	var limited *io.LimitedReader
+	if o.byteRange > 0 {
+		limited = &io.LimitedReader{R: resp.Body, N: o.byteRange}
+		body = limited
+		if contentLength < 0 || contentLength > o.byteRange {
+			contentLength = o.byteRange
+		}
+	}
+
+	s, err := parse(body, contentLength, o, o.parser.Parse)
+	if err != nil {
+		return nil, err
+	}
+	if limited != nil && limited.N == 0 {
+		s.truncated = s.truncated || rangeTruncated(resp)
+	}
+
+	s.url = parsedURL
+	if resp.Request != nil && resp.Request.URL != nil {
+		s.url = resp.Request.URL
+	}
+	if resp.Request != nil {
+		s.profile, _ = ProfileFromContext(resp.Request.Context())
+	}
+	if o.preferAMP {
+		return s.preferAMP(client, opts), nil
+	}
+
+	return s, nil
+}
+
+// parse builds a Scraper from body applying options, parseFunc turns body into a document node.
+// contentLength is used to estimate the DOM size, it is -1 if unknown.
+func parse(body io.Reader, contentLength int64, o options, parseFunc func(io.Reader) (*html.Node, error)) (*Scraper, error) {
+	if o.budget != nil {
+		release := o.budget.acquire(estimateDOMSize(contentLength))
+		defer release()
+	}
+
+	var hasher hash.Hash
+	if o.cache != nil {
+		hasher = sha256.New()
+		body = io.TeeReader(body, hasher)
+	}
+
+	var partial *partialReader
+	if o.partialBody {
+		partial = &partialReader{r: body}
+		body = partial
+	}
+
+	var source *bytes.Buffer
+	if o.diagnostics {
+		source = &bytes.Buffer{}
+		body = io.TeeReader(body, source)
+	}
+
+	doc, err := parseFunc(body)
 	if err != nil {
 		return nil, fmt.Errorf("parse content as HTML: %s", err)
 	}
 
-	return &Scraper{
-		doc: doc,
-	}, nil
+	s := &Scraper{doc: doc, limits: o.limits, timings: o.timings, caseInsensitive: o.caseInsensitive, options: o}
+	if partial != nil && partial.err != nil {
+		log.Printf("read body error: %s. Parsing the received part", partial.err.Error())
+		s.truncated = true
+	}
+	if source != nil {
+		d := diagnose(source.Bytes(), doc)
+		s.diagnostics = &d
+	}
+	if o.noscript {
+		if err = expandNoscript(doc); err != nil {
+			return nil, err
+		}
+		if hasher != nil {
+			hasher.Write([]byte("with noscript content"))
+		}
+	}
+	if o.caseInsensitive && hasher != nil {
+		hasher.Write([]byte("case-insensitive"))
+	}
+	if o.removeConsent {
+		removeConsentOverlays(doc)
+		if hasher != nil {
+			// paths resolve differently in the same body without overlays, so their cached values must not be shared
+			hasher.Write([]byte("without consent overlays"))
+		}
+	}
+	if hasher != nil {
+		s.cache, s.hash = o.cache, hex.EncodeToString(hasher.Sum(nil))
+	}
+	return s, nil
+}
+
+// URL returns the address the document was fetched from after redirects, nil if it is unknown
+func (s *Scraper) URL() *url.URL {
+	if s.url == nil {
+		return nil
+	}
+	u := *s.url
+	return &u
 }
 
 func (s *Scraper) GetValue(fullXPath string) (string, error) {
-	node, err := s.FindNode(fullXPath)
+	return s.GetValueContext(context.Background(), fullXPath)
+}
+
+// GetValueContext is GetValue stopping path evaluation when ctx is done
+func (s *Scraper) GetValueContext(ctx context.Context, fullXPath string) (string, error) {
+	if s.cache == nil {
+		return s.getValue(ctx, fullXPath)
+	}
+
+	key := extractionKey{hash: s.hash, path: fullXPath}
+	if entry, ok := s.cache.get(key); ok {
+		return entry.value, entry.err
+	}
+	value, err := s.getValue(ctx, fullXPath)
+	if ctx.Err() == nil {
+		s.cache.put(key, value, err)
+	}
+
+	return value, err
+}
+
+func (s *Scraper) getValue(ctx context.Context, fullXPath string) (string, error) {
+	node, err := s.findContext(ctx, fullXPath)
 	if err != nil {
 		return "", err
 	}
 
+	return nodeValue(node)
+}
+
+// nodeValue returns the data of a text node or error if node isn't text
+func nodeValue(node *html.Node) (string, error) {
 	if node.Type == html.TextNode {
 		return node.Data, nil
 	} else {
@@ -101,80 +272,476 @@ func (s *Scraper) GetValue(fullXPath string) (string, error) {
 }
 
 func (s *Scraper) NextAfter(fullXPath string) ([]*html.Node, error) {
-	node, err := s.FindNode(fullXPath)
+	node, err := s.find(fullXPath)
 	if err != nil {
 		return nil, err
 	}
+	if s.frozen {
+		node = detach(node)
+	}
 
-	return collectAfter(node), nil
+	return collectAfter(node, s.limits)
 }
 
 func (s *Scraper) GetChildes(fullXPath string) ([]*html.Node, error) {
-	node, err := s.FindNode(fullXPath)
+	node, err := s.find(fullXPath)
+	if err != nil {
+		return nil, err
+	}
+	if s.frozen {
+		node = cloneNode(node)
+	}
+
+	return collectAfter(node.FirstChild, s.limits)
+}
+
+// FindNode returns the first node in document order matching fullXPath. Besides positions like div[2],
+// steps may be filtered by attributes: div[@class="price-value"], div[@id!='main'] or div[@data-id].
+// A step after "//" matches descendants at any depth, like //span[@itemprop="price"], and * matches any element.
+// Predicates may call contains(), starts-with(), normalize-space() and text(), like //a[contains(@href, "/product/")],
+// and select by position with last(), position() and comparisons, like tr[last()] or li[position()<3].
+// text() in predicates selects every text child, so text()="b" and contains(text(), "b") hold if any of them does.
+// Paths copied from browsers may end with text(), matching the joined text of an element, or with @name,
+// matching the value of an attribute. Both are returned as text nodes detached from the document.
+// Paths separated by "|" match nodes matching any of them, like //div[@class="price"] | //span[@class="price-new"].
+// Steps may select the parent with "..", ancestors with ancestor:: and siblings with following-sibling:: and
+// preceding-sibling::, like //td[text()="Price"]/following-sibling::td[1]. A frozen Scraper returns a copy
+// detached from the document, so such steps find nothing from it.
+func (s *Scraper) FindNode(fullXPath string) (*html.Node, error) {
+	return s.FindNodeContext(context.Background(), fullXPath)
+}
+
+// FindNodeContext is FindNode stopping path evaluation when ctx is done
+func (s *Scraper) FindNodeContext(ctx context.Context, fullXPath string) (*html.Node, error) {
+	node, err := s.findContext(ctx, fullXPath)
 	if err != nil {
 		return nil, err
 	}
+	if s.frozen {
+		return cloneNode(node), nil
+	}
+
+	return node, nil
+}
 
-	return collectAfter(node.FirstChild), nil
+// FindNodes returns all nodes matching fullXPath in document order, empty if there are none.
+// A frozen Scraper returns copies without parent and siblings.
+func (s *Scraper) FindNodes(fullXPath string) ([]*html.Node, error) {
+	return s.FindNodesContext(context.Background(), fullXPath)
 }
 
-func collectAfter(node *html.Node) []*html.Node {
+// FindNodesContext is FindNodes stopping path evaluation when ctx is done
+func (s *Scraper) FindNodesContext(ctx context.Context, fullXPath string) ([]*html.Node, error) {
+	nodes, err := s.findAllContext(ctx, fullXPath)
+	if err != nil {
+		return nil, err
+	}
+	if s.frozen {
+		for i, n := range nodes {
+			nodes[i] = cloneNode(n)
+		}
+	}
+
+	return nodes, nil
+}
+
+// FindCompiled is FindNode for a compiled path, the value of a matched text node is its Data.
+// A frozen Scraper returns a copy without parent and siblings.
+func (s *Scraper) FindCompiled(p *Path) (*html.Node, error) {
+	return s.FindCompiledContext(context.Background(), p)
+}
+
+// FindCompiledContext is FindCompiled stopping path evaluation when ctx is done
+func (s *Scraper) FindCompiledContext(ctx context.Context, p *Path) (*html.Node, error) {
+	if p == nil {
+		return nil, errors.New("path should be not nil")
+	}
+	if s.timings != nil {
+		defer s.timings.track(p.expr, time.Now())
+	}
+
+	union := p.union
+	if s.caseInsensitive {
+		union = foldCase(union)
+	}
+	nodes, err := findUnion(ctx, union, s.doc, s.ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("element not found")
+	}
+	if s.frozen {
+		return cloneNode(nodes[0]), nil
+	}
+
+	return nodes[0], nil
+}
+
+// GetValues returns values of all text nodes matching fullXPath in document order, empty if there are none.
+// It fails if any of matched nodes isn't text.
+func (s *Scraper) GetValues(fullXPath string) ([]string, error) {
+	return s.GetValuesContext(context.Background(), fullXPath)
+}
+
+// GetValuesContext is GetValues stopping path evaluation when ctx is done
+func (s *Scraper) GetValuesContext(ctx context.Context, fullXPath string) ([]string, error) {
+	nodes, err := s.findAllContext(ctx, fullXPath)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		value, err := nodeValue(n)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func (s *Scraper) find(fullXPath string) (*html.Node, error) {
+	return s.findContext(context.Background(), fullXPath)
+}
+
+func (s *Scraper) findContext(ctx context.Context, fullXPath string) (*html.Node, error) {
+	nodes, err := s.findAllContext(ctx, fullXPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("element not found")
+	}
+
+	return nodes[0], nil
+}
+
+func (s *Scraper) findAllContext(ctx context.Context, fullXPath string) ([]*html.Node, error) {
+	if s.timings != nil {
+		defer s.timings.track(fullXPath, time.Now())
+	}
+
+	paths, err := s.parsePaths(fullXPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return findUnion(ctx, paths, s.doc, s.ids)
+}
+
+// findUnion returns nodes matching any of paths in document order without duplicates,
+// ids is the index of elements under rootNode by id or nil
+func findUnion(ctx context.Context, paths [][]PathStep, rootNode *html.Node, ids map[string][]*html.Node) ([]*html.Node, error) {
 	var nodes []*html.Node
+	for _, path := range paths {
+		found, err := findPath(ctx, path, rootNode, ids)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, found...)
+	}
+	if len(paths) > 1 {
+		nodes = documentOrder(nodes)
+	}
 
-	for n := node; n != nil; n = n.NextSibling {
-		nodes = append(nodes, n)
-		nodes = append(nodes, collectAfter(n.FirstChild)...)
+	return nodes, nil
+}
+
+// findNodes returns nodes matching the path from rootNodes in document order, empty if there are none
+func findNodes(ctx context.Context, path []PathStep, rootNodes ...*html.Node) ([]*html.Node, error) {
+	nodes := rootNodes
+	// nested is set once nodes may contain descendants of each other, children of them are not in document order
+	var nested bool
+	for i, step := range path {
+		next, nextNested, err := findStep(ctx, step, nodes, nested)
+		if err != nil {
+			return nil, err
+		}
+		if i == len(path)-1 && step.Tag == textFunction {
+			next = joinText(next)
+		}
+		if len(next) == 0 {
+			return nil, nil
+		}
+		nodes, nested = next, nextNested
 	}
 
-	return nodes
+	return nodes, nil
 }
 
-func (s *Scraper) FindNode(fullXPath string) (*html.Node, error) {
-	if !utf8.ValidString(fullXPath) {
-		return nil, errors.New("fullXPath is not valid utf8 string")
+// findStep returns the nodes the path step selects from nodes and whether they may contain descendants of each other,
+// nested tells the same about nodes
+func findStep(ctx context.Context, step PathStep, nodes []*html.Node, nested bool) ([]*html.Node, bool, error) {
+	if step.isAttribute() {
+		return attributeValues(step.Tag[1:], nodes), nested, nil
+	}
+	if step.Axis != "" {
+		found, err := findAxis(ctx, step, nodes)
+		return found, true, err
+	}
+	if step.Descendant {
+		descendants, err := findDescendants(ctx, step, nodes)
+		return descendants, true, err
 	}
 
-	if !strings.HasPrefix(fullXPath, pathDelimiter) {
-		return nil, fmt.Errorf("should have a prefix \"/\"")
+	var next []*html.Node
+	for _, node := range nodes {
+		children, err := findChildren(ctx, step, node)
+		if err != nil {
+			return nil, false, err
+		}
+		next = append(next, children...)
+	}
+	if nested && len(nodes) > 1 {
+		next = documentOrder(next)
 	}
 
-	return findNode(strings.Split(fullXPath[1:], pathDelimiter)[1:], s.doc.FirstChild.NextSibling)
+	return next, nested, nil
 }
 
-func findNode(path []string, rootNode *html.Node) (*html.Node, error) {
-	if len(path) == 0 {
-		return rootNode, nil
-	} else {
-		rootNode = rootNode.FirstChild
+// findChildren returns children of parent matching the path step in document order
+func findChildren(ctx context.Context, step PathStep, parent *html.Node) ([]*html.Node, error) {
+	predicates := step.predicates
+	// the n-th child is selected without collecting all matching children, as in most of full XPaths
+	var position uint
+	if len(predicates) != 0 {
+		if n, ok := predicates[0].(numberExpr); ok {
+			position, predicates = n.value, predicates[1:]
+		}
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("evaluate path: %w", err)
+	}
 	var (
-		targetTagName      = path[0]
-		tagsCount     uint = 1
+		nodes     []*html.Node
+		tagsCount uint
 	)
+	for i, n := 0, parent.FirstChild; n != nil; i, n = i+1, n.NextSibling {
+		if i%contextCheckInterval == contextCheckInterval-1 {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("evaluate path: %w", err)
+			}
+		}
+		if !step.matches(n) {
+			continue
+		}
+		tagsCount++
+		if position == 0 {
+			nodes = append(nodes, n)
+		} else if tagsCount == position {
+			nodes = append(nodes, n)
+			break
+		}
+	}
 
-	tagNum, err := parseElement(targetTagName)
-	if err != nil {
-		return nil, fmt.Errorf("parse element number: %w", err)
+	return filterNodes(nodes, predicates, step.fold), nil
+}
+
+// findDescendants returns descendants of roots matching the path step in document order.
+// Positions in predicates are counted among siblings, as in XPath //div[2] selects every second div child.
+func findDescendants(ctx context.Context, step PathStep, roots []*html.Node) ([]*html.Node, error) {
+	var (
+		nodes []*html.Node
+		last  *html.Node
+		err   error
+	)
+	for _, root := range roots {
+		// roots are in document order, so a root nested in another one follows it and its descendants are already visited
+		if last != nil && isAncestor(last, root) {
+			continue
+		}
+		last = root
+
+		var i int
+		walk(root, func(n *html.Node) bool {
+			if i++; i%contextCheckInterval == 0 {
+				if err = ctx.Err(); err != nil {
+					return false
+				}
+			}
+			if n != root && step.matches(n) {
+				nodes = append(nodes, n)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("evaluate path: %w", err)
+		}
+	}
+	if len(step.predicates) == 0 {
+		return nodes, nil
+	}
+
+	siblings := make(map[*html.Node][]*html.Node)
+	for _, n := range nodes {
+		siblings[n.Parent] = append(siblings[n.Parent], n)
+	}
+	matched := make(map[*html.Node]bool)
+	for _, group := range siblings {
+		for _, n := range filterNodes(group, step.predicates, step.fold) {
+			matched[n] = true
+		}
+	}
+
+	filtered := nodes[:0]
+	for _, n := range nodes {
+		if matched[n] {
+			filtered = append(filtered, n)
+		}
+	}
+
+	return filtered, nil
+}
+
+// findAxis returns nodes on the axis of the step from nodes matching the step in document order without duplicates.
+// Positions in predicates are counted from the context node, so preceding-sibling::td[1] is the nearest preceding td.
+func findAxis(ctx context.Context, step PathStep, nodes []*html.Node) ([]*html.Node, error) {
+	next := func(n *html.Node) *html.Node { return n.Parent }
+	switch step.Axis {
+	case axisFollowingSibling:
+		next = func(n *html.Node) *html.Node { return n.NextSibling }
+	case axisPrecedingSibling:
+		next = func(n *html.Node) *html.Node { return n.PrevSibling }
+	}
+
+	var (
+		found []*html.Node
+		i     int
+	)
+	for _, node := range nodes {
+		var candidates []*html.Node
+		for n := next(node); n != nil; n = next(n) {
+			if i++; i%contextCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return nil, fmt.Errorf("evaluate path: %w", err)
+				}
+			}
+			if step.matches(n) {
+				candidates = append(candidates, n)
+			}
+			if step.Axis == axisParent {
+				break
+			}
+		}
+		found = append(found, filterNodes(candidates, step.predicates, step.fold)...)
+	}
+
+	return documentOrder(found), nil
+}
+
+// attributeValues returns detached text nodes holding values of the attribute of nodes having it
+func attributeValues(name string, nodes []*html.Node) []*html.Node {
+	var values []*html.Node
+	for _, n := range nodes {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		if value, ok := attr(n, name); ok {
+			values = append(values, &html.Node{Type: html.TextNode, Data: value})
+		}
+	}
+
+	return values
+}
+
+// joinText replaces text nodes of the same parent with a detached text node holding their concatenated text,
+// so text() of an element with inline children like "<p>1 <b>2</b> 3</p>" returns "1  3" rather than "1 "
+func joinText(nodes []*html.Node) []*html.Node {
+	var (
+		parents []*html.Node
+		texts   = make(map[*html.Node][]*html.Node)
+	)
+	for _, n := range nodes {
+		if _, ok := texts[n.Parent]; !ok {
+			parents = append(parents, n.Parent)
+		}
+		texts[n.Parent] = append(texts[n.Parent], n)
 	}
 
-	if strings.ContainsRune(targetTagName, '[') {
-		targetTagName = targetTagName[:strings.IndexByte(targetTagName, '[')]
+	joined := make([]*html.Node, 0, len(parents))
+	for _, parent := range parents {
+		group := texts[parent]
+		if len(group) == 1 {
+			joined = append(joined, group[0])
+			continue
+		}
+		var b strings.Builder
+		for _, n := range group {
+			b.WriteString(n.Data)
+		}
+		joined = append(joined, &html.Node{Type: html.TextNode, Data: b.String()})
 	}
 
-	for n := rootNode; n != nil; n = n.NextSibling {
-		if (n.Type == html.TextNode && strings.HasPrefix(targetTagName, "text")) ||
-			n.Data == targetTagName {
+	return joined
+}
 
-			if tagsCount == tagNum {
-				return findNode(path[1:], n)
-			} else {
-				tagsCount++
+// filterNodes returns nodes matching all predicates, each predicate is evaluated with positions among nodes
+// left by the previous one
+func filterNodes(nodes []*html.Node, predicates []pathExpr, fold bool) []*html.Node {
+	for _, predicate := range predicates {
+		filtered := nodes[:0:0]
+		for i, n := range nodes {
+			if matches(predicate, exprContext{node: n, position: i + 1, size: len(nodes), fold: fold}) {
+				filtered = append(filtered, n)
 			}
 		}
+		nodes = filtered
 	}
 
-	return nil, errors.New("element not found")
+	return nodes
+}
+
+// documentOrder returns nodes sorted in document order without duplicates. Nodes detached from the document,
+// like attribute values, follow in the given order.
+func documentOrder(nodes []*html.Node) []*html.Node {
+	if len(nodes) < 2 {
+		return nodes
+	}
+	var root *html.Node
+	left := make(map[*html.Node]bool, len(nodes))
+	for _, n := range nodes {
+		left[n] = true
+		if root == nil && n.Parent != nil {
+			root = n
+		}
+	}
+
+	ordered := make([]*html.Node, 0, len(left))
+	if root != nil {
+		for root.Parent != nil {
+			root = root.Parent
+		}
+		walk(root, func(n *html.Node) bool {
+			if left[n] {
+				ordered = append(ordered, n)
+				delete(left, n)
+			}
+			return len(left) != 0
+		})
+	}
+	for _, n := range nodes {
+		if left[n] {
+			ordered = append(ordered, n)
+			delete(left, n)
+		}
+	}
+
+	return ordered
+}
+
+// isAncestor reports whether ancestor is a proper ancestor of n
+func isAncestor(ancestor, n *html.Node) bool {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p == ancestor {
+			return true
+		}
+	}
+
+	return false
 }
 
 // parseElement parses html element by path, returns its number or error if occurred
@@ -203,10 +770,13 @@ func parseElement(path string) (uint, error) {
 		return 1, nil
 	}
 
-	n, err := strconv.Atoi(path[o+1 : c])
+	n, err := strconv.ParseUint(path[o+1:c], 10, 0)
 	if err != nil {
 		return 0, fmt.Errorf("convert string to int: %w", err)
 	}
+	if n == 0 {
+		return 0, errors.New("the tag number should be positive")
+	}
 
 	return uint(n), nil
 }
@@ -241,24 +811,47 @@ func getSquareBracketsIndexes(s string) (o int, c int) {
 	return strings.IndexByte(s, openSquareBracket), strings.IndexByte(s, closeSquareBracket)
 }
 
-func NewHTTPClientWithRetry(retries uint, retryTimeout time.Duration) (HTTPClient, error) {
+func NewHTTPClientWithRetry(retries uint, retryTimeout time.Duration, opts ...ClientOption) (HTTPClient, error) {
 	if retryTimeout < 0 {
 		return nil, errors.New("retryTimeout should not be negative")
 	}
 
-	return &httpClientWithRetry{
+	c := &httpClientWithRetry{
 		client:       cleanhttp.DefaultClient(),
 		retries:      retries,
 		retryTimeout: retryTimeout,
-	}, nil
+		retryPolicy:  DefaultRetryPolicy,
+		dialer:       newDialer(),
+		clock:        SystemClock,
+		sleeper:      SystemSleeper,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, fmt.Errorf("apply client option: %w", err)
+		}
+	}
+	if c.policies != nil {
+		c.client.CheckRedirect = c.policies.checkRedirect
+	}
+	if transport, ok := c.client.Transport.(*http.Transport); ok {
+		transport.DialContext = c.dialer.DialContext
+		if c.proxy != nil {
+			transport.Proxy = c.proxy
+		}
+		if len(c.hosts) != 0 {
+			transport.Proxy = c.proxyFor(transport.Proxy)
+		}
+		if transport.Proxy != nil {
+			transport.Proxy = recordProxy(transport.Proxy)
+		}
+	}
+
+	return c, nil
 }
 
 func defaultHTTPClientWithRetry() HTTPClient {
-	return &httpClientWithRetry{
-		client:       cleanhttp.DefaultClient(),
-		retries:      3,
-		retryTimeout: 30 * time.Second,
-	}
+	c, _ := NewHTTPClientWithRetry(3, 30*time.Second)
+	return c
 }
 
 func (c *httpClientWithRetry) Get(url *url.URL) (*http.Response, error) {
@@ -269,24 +862,100 @@ func (c *httpClientWithRetry) Get(url *url.URL) (*http.Response, error) {
 		return nil, errors.New("retryTimeout should not be negative")
 	}
 
-	req := &http.Request{Method: http.MethodGet, URL: url, Header: make(map[string][]string)}
+	req := c.newRequest(url)
+	resp, err := c.do(req)
+
+	return resp, requestIDError(req, err)
+}
+
+func (c *httpClientWithRetry) newRequest(url *url.URL) *http.Request {
+	req := &http.Request{Method: http.MethodGet, URL: c.rewriteHost(url), Header: make(map[string][]string)}
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Accept-Charset", "utf-8")
+	for key, values := range c.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	for _, cookie := range c.cookies {
+		req.AddCookie(cookie)
+	}
+	if h := c.hostConfigFor(req.URL); h != nil {
+		for key, values := range h.header {
+			req.Header[key] = append([]string(nil), values...)
+		}
+		for _, cookie := range h.cookies {
+			req.AddCookie(cookie)
+		}
+	}
 
+	return c.setRequestID(c.setProfile(withChosenProxy(req)))
+}
+
+// setHeader sets a header added to every request made by the client
+func (c *httpClientWithRetry) setHeader(key, value string) {
+	if c.header == nil {
+		c.header = make(http.Header)
+	}
+	c.header.Set(key, value)
+}
+
+// do performs req retrying transport errors according to the retry policy
+func (c *httpClientWithRetry) do(req *http.Request) (*http.Response, error) {
 	var (
-		err  error
-		resp *http.Response
+		transportErr    *TransportError
+		forbiddenErr    *ForbiddenDomainError
+		reauthenticated bool
 	)
-	for retry := int(c.retries); retry >= 0; retry-- {
-		resp, err = c.client.Do(req)
+	if c.policies != nil {
+		if err := c.policies.Check(req.URL.Hostname()); err != nil {
+			return nil, err
+		}
+	}
+	for attempt, retry := uint(1), int(c.retries); retry >= 0; attempt, retry = attempt+1, retry-1 {
+		if err := c.authenticate(req); err != nil {
+			return nil, fmt.Errorf("authenticate request: %w", err)
+		}
+		attemptReq, cancel := c.withTimeout(req)
+		resp, err := c.client.Do(attemptReq)
 		if err == nil {
+			if attemptReq != req {
+				resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			}
+			c.recordAttempt(req, attempt, resp.StatusCode, nil, false, 0)
+			// a cached token may be revoked before its expiry, it is refreshed once without spending a retry
+			if resp.StatusCode == http.StatusUnauthorized && !reauthenticated && c.invalidateAuth(req) {
+				reauthenticated = true
+				if closeErr := resp.Body.Close(); closeErr != nil {
+					log.Printf("%sresp body close error: %s", requestLogPrefix(req), closeErr.Error())
+				}
+				retry++
+				continue
+			}
 			return resp, nil
 		}
-		log.Printf("perform GET request error: %s. Retrying", err.Error())
+		cancel()
+		transportErr = ClassifyTransportError(err)
+		if errors.As(err, &forbiddenErr) {
+			c.recordAttempt(req, attempt, 0, transportErr, false, 0)
+			return nil, forbiddenErr
+		}
+
+		var (
+			again bool
+			wait  time.Duration
+		)
 		if retry > 0 {
-			time.Sleep(c.retryTimeout)
+			again, wait = c.retryPolicy(transportErr, c.retryTimeout)
+		}
+		c.recordAttempt(req, attempt, 0, transportErr, again, wait)
+		if retry == 0 {
+			break
+		}
+		if !again {
+			return nil, fmt.Errorf("perform GET request: %w", transportErr)
 		}
+		log.Printf("%sperform GET request error: %s. Retrying", requestLogPrefix(req), transportErr.Error())
+		c.sleeper.Sleep(wait)
 	}
 
-	return nil, fmt.Errorf("execution request timeout: %w", err)
+	return nil, fmt.Errorf("execution request timeout: %w", transportErr)
 }
@@ -71,7 +71,7 @@ func TestNew(t *testing.T) {
 				webAddress: "https://someAddress",
 				client:     &httpClientWithoutError{},
 			},
-			want: &Scraper{doc: correctNode},
+			want: &Scraper{doc: correctNode, url: &url.URL{Scheme: "https", Host: "someAddress"}, options: options{parser: DefaultParser}},
 		},
 		{
 			name: "nullable client",
@@ -395,6 +395,7 @@ func TestScraperGetValue(t *testing.T) {
 	r, _ := (&httpClientWithoutError{}).Get(nil)
 	correctDoc, _ := html.Parse(r.Body)
 	_ = r.Body.Close()
+	withoutDoctype, _ := html.Parse(strings.NewReader("<p>value</p>"))
 
 	type fields struct {
 		doc *html.Node
@@ -439,6 +440,18 @@ func TestScraperGetValue(t *testing.T) {
 			args:    args{fullXPath: "/html/body/div[1]/div[1]/div[1]/div[2]/div[3]/div/div[2]/div/div[1]/div[1]/div/span[1]/span/span/div"},
 			wantErr: true,
 		},
+		{
+			name:   "document without doctype",
+			fields: fields{doc: withoutDoctype},
+			args:   args{fullXPath: "/html/body/p/text"},
+			want:   "value",
+		},
+		{
+			name:    "wrong root element",
+			fields:  fields{doc: withoutDoctype},
+			args:    args{fullXPath: "/body/p/text"},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -457,7 +470,86 @@ func TestScraperGetValue(t *testing.T) {
 	}
 }
 
+func TestScraperGetValues(t *testing.T) {
+	s := newTestScraper(t, `<ul>
+<li><span class="name">One</span><span class="price">10</span></li>
+<li><span class="name">Two</span></li>
+<li><span class="name">Three</span><span class="price">30</span></li>
+</ul>`)
+
+	tests := []struct {
+		name      string
+		fullXPath string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:      "all items",
+			fullXPath: `/html/body/ul/li/span[@class="name"]/text`,
+			want:      []string{"One", "Two", "Three"},
+		},
+		{
+			name:      "missing in some items",
+			fullXPath: `/html/body/ul/li/span[@class="price"]/text`,
+			want:      []string{"10", "30"},
+		},
+		{
+			name:      "none",
+			fullXPath: "/html/body/table/text",
+			want:      []string{},
+		},
+		{
+			name:      "not text",
+			fullXPath: "/html/body/ul/li",
+			wantErr:   true,
+		},
+		{
+			name:      "invalid path",
+			fullXPath: "html/body",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetValues(tt.fullXPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValues() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraperFindNodes(t *testing.T) {
+	s := newTestScraper(t, `<p>a</p><p>b</p>`).Freeze()
+
+	nodes, err := s.FindNodes("/html/body/p")
+	if err != nil {
+		t.Fatalf("FindNodes() error = %v", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("FindNodes() got %d nodes, want 2", len(nodes))
+	}
+	nodes[1].FirstChild.Data = "changed"
+	if got, _ := s.GetValues("/html/body/p/text"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("GetValues() got = %v after changing a found node of a frozen scraper", got)
+	}
+}
+
 func readTestTagPaths() string {
 	b, _ := os.ReadFile("./test-data/tagPaths.txt")
 	return string(b)
 }
+
+func newTestScraper(t testing.TB, content string) *Scraper {
+	t.Helper()
+
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("parse test document: %v", err)
+	}
+
+	return &Scraper{doc: doc}
+}
@@ -0,0 +1,157 @@
+package scraper
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+type (
+	// SEOReport is a summary of on-page SEO signals of a document
+	SEOReport struct {
+		URL                   string
+		Title                 string
+		TitleLength           int
+		MetaDescription       string
+		MetaDescriptionLength int
+		H1Count               int
+		Canonical             string
+		Hreflang              []HreflangLink
+		RobotsMeta            string
+		Images                int
+		ImagesWithAlt         int
+		// ImageAltCoverage is a share of images with non-empty alt text, 1 if there are no images
+		ImageAltCoverage float64
+		// StructuredDataTypes are @type values of JSON-LD blocks and itemtype values of microdata
+		StructuredDataTypes []string
+	}
+
+	// HreflangLink is an alternate language version of a page
+	HreflangLink struct {
+		Lang string
+		Href string
+	}
+)
+
+// HasStructuredData reports whether the page contains JSON-LD or microdata
+func (r SEOReport) HasStructuredData() bool {
+	return len(r.StructuredDataTypes) != 0
+}
+
+// SEOAudit extracts SEO signals of the document
+func (s *Scraper) SEOAudit() SEOReport {
+	var report SEOReport
+	if s.url != nil {
+		report.URL = s.url.String()
+	}
+
+	if titles := elements(s.doc, "title"); len(titles) != 0 {
+		report.Title = strings.TrimSpace(textContent(titles[0]))
+		report.TitleLength = utf8.RuneCountInString(report.Title)
+	}
+	report.MetaDescription, _ = metaContent(s.doc, "description")
+	report.MetaDescriptionLength = utf8.RuneCountInString(report.MetaDescription)
+	report.RobotsMeta, _ = metaContent(s.doc, "robots")
+	report.H1Count = len(elements(s.doc, "h1"))
+
+	for _, l := range elements(s.doc, "link") {
+		rel, _ := attr(l, "rel")
+		href, _ := attr(l, "href")
+		switch {
+		case hasToken(rel, "canonical") && report.Canonical == "":
+			report.Canonical = strings.TrimSpace(href)
+		case hasToken(rel, "alternate"):
+			if lang, ok := attr(l, "hreflang"); ok {
+				report.Hreflang = append(report.Hreflang, HreflangLink{Lang: strings.TrimSpace(lang), Href: strings.TrimSpace(href)})
+			}
+		}
+	}
+
+	for _, img := range elements(s.doc, "img") {
+		report.Images++
+		if alt, _ := attr(img, "alt"); strings.TrimSpace(alt) != "" {
+			report.ImagesWithAlt++
+		}
+	}
+	report.ImageAltCoverage = 1
+	if report.Images != 0 {
+		report.ImageAltCoverage = float64(report.ImagesWithAlt) / float64(report.Images)
+	}
+
+	report.StructuredDataTypes = structuredDataTypes(s.doc)
+
+	return report
+}
+
+func structuredDataTypes(root *html.Node) []string {
+	var types []string
+	for _, v := range jsonLD(root) {
+		types = append(types, jsonLDTypes(v)...)
+	}
+	walk(root, func(n *html.Node) bool {
+		if n.Type == html.ElementNode {
+			if itemType, ok := attr(n, "itemtype"); ok && strings.TrimSpace(itemType) != "" {
+				types = append(types, strings.TrimSpace(itemType))
+			}
+		}
+		return true
+	})
+
+	return types
+}
+
+// jsonLD returns decoded contents of all parsable JSON-LD scripts of the document
+func jsonLD(root *html.Node) []any {
+	var values []any
+	for _, script := range elements(root, "script") {
+		if t, _ := attr(script, "type"); !strings.EqualFold(strings.TrimSpace(t), "application/ld+json") {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal([]byte(textContent(script)), &v); err != nil {
+			continue
+		}
+		values = append(values, v)
+	}
+
+	return values
+}
+
+// jsonLDObjects flattens top level arrays and @graph containers of a JSON-LD value into objects
+func jsonLDObjects(v any) []map[string]any {
+	switch t := v.(type) {
+	case []any:
+		var objects []map[string]any
+		for _, item := range t {
+			objects = append(objects, jsonLDObjects(item)...)
+		}
+		return objects
+	case map[string]any:
+		if graph, ok := t["@graph"]; ok {
+			return jsonLDObjects(graph)
+		}
+		return []map[string]any{t}
+	default:
+		return nil
+	}
+}
+
+func jsonLDTypes(v any) []string {
+	var types []string
+	for _, o := range jsonLDObjects(v) {
+		switch t := o["@type"].(type) {
+		case string:
+			types = append(types, t)
+		case []any:
+			for _, item := range t {
+				if s, ok := item.(string); ok {
+					types = append(types, s)
+				}
+			}
+		}
+	}
+
+	return types
+}
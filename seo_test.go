@@ -0,0 +1,48 @@
+package scraper
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestScraperSEOAudit(t *testing.T) {
+	s, err := New("https://someAddress", &httpClientWithoutError{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	got := s.SEOAudit()
+	if got.URL != "https://someAddress" {
+		t.Errorf("SEOAudit() URL got = %v, want %v", got.URL, "https://someAddress")
+	}
+	if got.TitleLength == 0 || got.MetaDescriptionLength == 0 {
+		t.Errorf("SEOAudit() title or description is empty: %+v", got)
+	}
+	if want := "https://hotline.ua/computer-videokarty/gigabyte-geforce-gtx-1060-g1-gaming-6g-gv-n1060g1-gaming-6gd/"; got.Canonical != want {
+		t.Errorf("SEOAudit() Canonical got = %v, want %v", got.Canonical, want)
+	}
+	if want := []string{"BreadcrumbList", "Product"}; !reflect.DeepEqual(got.StructuredDataTypes, want) {
+		t.Errorf("SEOAudit() StructuredDataTypes got = %v, want %v", got.StructuredDataTypes, want)
+	}
+	if got.Images == 0 || got.ImageAltCoverage <= 0 || got.ImageAltCoverage > 1 {
+		t.Errorf("SEOAudit() unexpected image stats: %d images, %v coverage", got.Images, got.ImageAltCoverage)
+	}
+}
+
+func TestScraperURL(t *testing.T) {
+	if got := (&Scraper{}).URL(); got != nil {
+		t.Errorf("URL() got = %v, want nil", got)
+	}
+
+	want := &url.URL{Scheme: "https", Host: "someAddress"}
+	s := &Scraper{url: want}
+	got := s.URL()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("URL() got = %v, want %v", got, want)
+	}
+	got.Host = "changed"
+	if s.url.Host != "someAddress" {
+		t.Errorf("URL() returned the internal value")
+	}
+}
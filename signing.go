@@ -0,0 +1,135 @@
+package scraper
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers set by the HMAC signer
+const (
+	HeaderSignatureKeyID     = "X-Signature-Key-Id"
+	HeaderSignatureTimestamp = "X-Signature-Timestamp"
+	HeaderSignatureNonce     = "X-Signature-Nonce"
+	HeaderSignature          = "X-Signature"
+)
+
+type (
+	// HMACSigner signs requests with HMAC-SHA256 of the method, host, request URI, Unix timestamp and a nonce,
+	// so receivers can verify requests come from holders of the secret and reject replays
+	HMACSigner struct {
+		keyID  string
+		secret []byte
+		nonce  func() (string, error)
+	}
+
+	// clockedAuthenticator is implemented by authenticators depending on the current time,
+	// the client calls it with the time of its Clock instead of Authenticate
+	clockedAuthenticator interface {
+		authenticateAt(req *http.Request, now time.Time) error
+	}
+)
+
+// NewHMACSigner returns a signer sending the signature, timestamp, nonce and keyID in the X-Signature headers.
+// Use it with WithSigner for internal hosts only, alone or together with an Authenticator of WithAuth.
+// Nonces are random if nonce is nil; a deterministic nonce makes signatures in recorded attempts reproducible,
+// but must not repeat within the skew receivers allow.
+func NewHMACSigner(keyID string, secret []byte, nonce func() string) (*HMACSigner, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("secret should be not empty")
+	}
+
+	s := &HMACSigner{keyID: keyID, secret: secret, nonce: randomNonce}
+	if nonce != nil {
+		s.nonce = func() (string, error) { return nonce(), nil }
+	}
+	return s, nil
+}
+
+// WithSigner makes the client sign requests to hosts matching hostPattern with signer after they are authenticated
+// by WithAuth. Patterns are checked in the order they were added and the first matching one is used.
+func WithSigner(hostPattern string, signer Authenticator) ClientOption {
+	return func(c *httpClientWithRetry) error {
+		if signer == nil {
+			return errors.New("signer should be not nil")
+		}
+		if err := validateHostPattern(hostPattern); err != nil {
+			return err
+		}
+		c.signers = append(c.signers, hostAuth{pattern: strings.ToLower(hostPattern), auth: signer})
+		return nil
+	}
+}
+
+// Authenticate signs req with the current time, clients created by NewHTTPClientWithRetry use their Clock instead
+func (s *HMACSigner) Authenticate(req *http.Request) error {
+	return s.authenticateAt(req, time.Now())
+}
+
+func (s *HMACSigner) authenticateAt(req *http.Request, now time.Time) error {
+	nonce, err := s.nonce()
+	if err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+	if nonce == "" {
+		return errors.New("generated nonce is empty")
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	req.Header.Set(HeaderSignatureKeyID, s.keyID)
+	req.Header.Set(HeaderSignatureTimestamp, timestamp)
+	req.Header.Set(HeaderSignatureNonce, nonce)
+	req.Header.Set(HeaderSignature, hmacSignature(s.secret, req.Method, req.URL.Host, req.URL.RequestURI(), timestamp, nonce))
+	return nil
+}
+
+func randomNonce() (string, error) {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(nonce[:]), nil
+}
+
+// VerifyHMACSignature checks the signature of a request received from a client signing with NewHMACSigner and
+// that it was signed at most maxSkew ago. Receivers should also reject nonces seen within maxSkew.
+func VerifyHMACSignature(req *http.Request, secret []byte, maxSkew time.Duration) error {
+	timestamp := req.Header.Get(HeaderSignatureTimestamp)
+	signed, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse signature timestamp [%s]: %w", timestamp, err)
+	}
+	if skew := time.Since(time.Unix(signed, 0)); skew > maxSkew || skew < -maxSkew {
+		return fmt.Errorf("signature timestamp is %s off", skew.Round(time.Second))
+	}
+
+	nonce := req.Header.Get(HeaderSignatureNonce)
+	if nonce == "" {
+		return errors.New("signature nonce is empty")
+	}
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	want := hmacSignature(secret, req.Method, host, req.URL.RequestURI(), timestamp, nonce)
+	if !hmac.Equal([]byte(want), []byte(req.Header.Get(HeaderSignature))) {
+		return errors.New("signature mismatch")
+	}
+
+	return nil
+}
+
+func hmacSignature(secret []byte, method, host, requestURI, timestamp, nonce string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join([]string{method, strings.ToLower(host), requestURI, timestamp, nonce}, "\n")))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,193 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestHMACSigner(t *testing.T) {
+	secret := []byte("fleet secret")
+	var verifyErr error
+	var keyID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifyErr, keyID = VerifyHMACSignature(r, secret, time.Minute), r.Header.Get(HeaderSignatureKeyID)
+		_, _ = w.Write([]byte("<p>ok</p>"))
+	}))
+	defer server.Close()
+
+	signer, err := NewHMACSigner("scraper-1", secret, nil)
+	if err != nil {
+		t.Fatalf("NewHMACSigner() error = %v", err)
+	}
+	client, err := NewHTTPClientWithRetry(0, 0, WithAuth("127.0.0.1", signer))
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+	if _, err = New(server.URL+"/internal?a=1", client); err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if verifyErr != nil || keyID != "scraper-1" {
+		t.Errorf("VerifyHMACSignature() error = %v, key id = %q", verifyErr, keyID)
+	}
+
+	if _, err = NewHMACSigner("empty", nil, nil); err == nil {
+		t.Errorf("NewHMACSigner() expected error for empty secret")
+	}
+}
+
+func TestWithSigner(t *testing.T) {
+	secret := []byte("fleet secret")
+	var verifyErr error
+	var auth, timestamp string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifyErr, auth, timestamp = VerifyHMACSignature(r, secret, time.Minute), r.Header.Get("Authorization"), r.Header.Get(HeaderSignatureTimestamp)
+		_, _ = w.Write([]byte("<p>ok</p>"))
+	}))
+	defer server.Close()
+
+	signer, err := NewHMACSigner("scraper-1", secret, nil)
+	if err != nil {
+		t.Fatalf("NewHMACSigner() error = %v", err)
+	}
+	signedAt := time.Now().Add(-30 * time.Second)
+	client, err := NewHTTPClientWithRetry(0, 0,
+		WithAuth("127.0.0.1", BearerToken("token")),
+		WithSigner("127.0.0.1", signer),
+		WithClock(FixedClock(signedAt)),
+	)
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+	}
+	if _, err = New(server.URL+"/internal", client); err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if verifyErr != nil || auth != "Bearer token" {
+		t.Errorf("VerifyHMACSignature() error = %v, Authorization = %q", verifyErr, auth)
+	}
+	if want := strconv.FormatInt(signedAt.Unix(), 10); timestamp != want {
+		t.Errorf("signature timestamp got = %v, want %v from the client clock", timestamp, want)
+	}
+
+	for _, opt := range []ClientOption{
+		WithSigner("*", nil),
+		WithSigner("[", signer),
+		WithHostOptions("*.test", WithSigner("*", signer)),
+	} {
+		if _, err = NewHTTPClientWithRetry(0, 0, opt); err == nil {
+			t.Errorf("NewHTTPClientWithRetry() expected error")
+		}
+	}
+}
+
+func TestVerifyHMACSignature(t *testing.T) {
+	secret := []byte("secret")
+	signer, _ := NewHMACSigner("key", secret, nil)
+
+	signed := func(modify func(*http.Request)) *http.Request {
+		u, _ := url.Parse("https://internal.test/page?id=1")
+		req := &http.Request{Method: http.MethodGet, URL: u, Header: make(http.Header)}
+		if err := signer.Authenticate(req); err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+		modify(req)
+		return req
+	}
+
+	tests := []struct {
+		name    string
+		req     *http.Request
+		secret  []byte
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			req:    signed(func(*http.Request) {}),
+			secret: secret,
+		},
+		{
+			name:    "other secret",
+			req:     signed(func(*http.Request) {}),
+			secret:  []byte("other"),
+			wantErr: true,
+		},
+		{
+			name:    "changed path",
+			req:     signed(func(r *http.Request) { r.URL.RawQuery = "id=2" }),
+			secret:  secret,
+			wantErr: true,
+		},
+		{
+			name: "stale",
+			req: signed(func(r *http.Request) {
+				r.Header.Set(HeaderSignatureTimestamp, strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+			}),
+			secret:  secret,
+			wantErr: true,
+		},
+		{
+			name:    "no nonce",
+			req:     signed(func(r *http.Request) { r.Header.Del(HeaderSignatureNonce) }),
+			secret:  secret,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyHMACSignature(tt.req, tt.secret, time.Minute); (err != nil) != tt.wantErr {
+				t.Errorf("VerifyHMACSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHMACSignerNonce(t *testing.T) {
+	var n int
+	signer, err := NewHMACSigner("key", []byte("secret"), func() string {
+		n++
+		return "nonce-" + strconv.Itoa(n)
+	})
+	if err != nil {
+		t.Fatalf("NewHMACSigner() error = %v", err)
+	}
+
+	signedAt := time.Now()
+	recorded := func() RetryAttempt {
+		n = 0
+		recorder := NewRetryRecorder()
+		client, err := NewHTTPClientWithRetry(0, 0,
+			WithSigner("*", signer),
+			WithRetryRecorder(recorder),
+			WithClock(FixedClock(signedAt)),
+		)
+		if err != nil {
+			t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+		}
+		client.(*httpClientWithRetry).client = &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		})}
+		target, _ := url.Parse("https://internal.test/page")
+		if _, err = client.Get(target); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		return recorder.Attempts()[0]
+	}
+
+	first, second := recorded(), recorded()
+	if got := first.Header.Get(HeaderSignatureNonce); got != "nonce-1" {
+		t.Errorf("recorded nonce got = %q, want %q", got, "nonce-1")
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("recorded attempts differ between runs: %+v and %+v", first, second)
+	}
+
+	empty, _ := NewHMACSigner("key", []byte("secret"), func() string { return "" })
+	req := &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "internal.test"}, Header: make(http.Header)}
+	if err = empty.Authenticate(req); err == nil {
+		t.Errorf("Authenticate() with an empty nonce expected error")
+	}
+}
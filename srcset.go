@@ -0,0 +1,176 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ImageCandidate is an image source of a srcset, Width is 0 if the candidate has a density descriptor
+type ImageCandidate struct {
+	URL     string
+	Width   int
+	Density float64
+}
+
+// ParseSrcset parses a srcset attribute value like "a.jpg 480w, b.jpg 800w" or "a.jpg, b.jpg 2x".
+// Candidates without descriptors have density 1, candidates with invalid descriptors are skipped.
+func ParseSrcset(srcset string) []ImageCandidate {
+	var candidates []ImageCandidate
+	for i := 0; i < len(srcset); {
+		for i < len(srcset) && (isHTMLSpace(srcset[i]) || srcset[i] == ',') {
+			i++
+		}
+		start := i
+		for i < len(srcset) && !isHTMLSpace(srcset[i]) {
+			i++
+		}
+		u := srcset[start:i]
+		if u == "" {
+			break
+		}
+
+		var descriptors string
+		if strings.HasSuffix(u, ",") {
+			u = strings.TrimRight(u, ",")
+		} else {
+			start = i
+			for depth := 0; i < len(srcset) && (srcset[i] != ',' || depth > 0); i++ {
+				switch srcset[i] {
+				case '(':
+					depth++
+				case ')':
+					depth--
+				}
+			}
+			descriptors = srcset[start:i]
+		}
+
+		if candidate, ok := parseImageCandidate(u, strings.Fields(descriptors)); ok {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates
+}
+
+func parseImageCandidate(u string, descriptors []string) (ImageCandidate, bool) {
+	candidate := ImageCandidate{URL: u}
+	for _, d := range descriptors {
+		switch {
+		case strings.HasSuffix(d, "w") && candidate.Width == 0 && candidate.Density == 0:
+			w, err := strconv.Atoi(d[:len(d)-1])
+			if err != nil || w <= 0 {
+				return ImageCandidate{}, false
+			}
+			candidate.Width = w
+		case strings.HasSuffix(d, "x") && candidate.Width == 0 && candidate.Density == 0:
+			x, err := strconv.ParseFloat(d[:len(d)-1], 64)
+			if err != nil || x <= 0 {
+				return ImageCandidate{}, false
+			}
+			candidate.Density = x
+		case strings.HasSuffix(d, "h"):
+			// height descriptors are reserved and ignored
+		default:
+			return ImageCandidate{}, false
+		}
+	}
+	if candidate.Width == 0 && candidate.Density == 0 {
+		candidate.Density = 1
+	}
+
+	return candidate, true
+}
+
+// SelectImageCandidate returns the largest candidate if targetWidth is 0, otherwise the narrowest candidate
+// at least targetWidth wide or the largest one if all are narrower. Width descriptors win over density ones.
+func SelectImageCandidate(candidates []ImageCandidate, targetWidth int) (ImageCandidate, bool) {
+	var best ImageCandidate
+	for i, c := range candidates {
+		if i == 0 || betterImageCandidate(c, best, targetWidth) {
+			best = c
+		}
+	}
+
+	return best, len(candidates) != 0
+}
+
+func betterImageCandidate(c, best ImageCandidate, targetWidth int) bool {
+	switch {
+	case c.Width == 0 && best.Width == 0:
+		return c.Density > best.Density
+	case c.Width == 0 || best.Width == 0:
+		return c.Width != 0
+	case targetWidth <= 0:
+		return c.Width > best.Width
+	case best.Width < targetWidth:
+		return c.Width > best.Width
+	default:
+		return c.Width >= targetWidth && c.Width < best.Width
+	}
+}
+
+// ImageURL returns the absolute URL of the best source of the img or picture element at fullXPath, see
+// SelectImageCandidate. Candidates come from srcset of the element and of sources of a picture, src and their
+// data- variants used for lazy loading. sizes is not evaluated, targetWidth stands for the rendered width.
+func (s *Scraper) ImageURL(fullXPath string, targetWidth int) (string, error) {
+	node, err := s.find(fullXPath)
+	if err != nil {
+		return "", err
+	}
+	if node.Type != html.ElementNode {
+		return "", errors.New("node is not an element")
+	}
+
+	var candidates []ImageCandidate
+	add := func(n *html.Node) {
+		for _, key := range []string{"srcset", "data-srcset"} {
+			if v, ok := attr(n, key); ok {
+				candidates = append(candidates, ParseSrcset(v)...)
+			}
+		}
+		for _, key := range []string{"src", "data-src"} {
+			if v, ok := attr(n, key); ok && strings.TrimSpace(v) != "" {
+				candidates = append(candidates, ImageCandidate{URL: strings.TrimSpace(v), Density: 1})
+			}
+		}
+	}
+
+	add(node)
+	if node.Data == "picture" {
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && (c.Data == "source" || c.Data == "img") {
+				add(c)
+			}
+		}
+	}
+
+	best, ok := SelectImageCandidate(candidates, targetWidth)
+	if !ok {
+		return "", errors.New("element has no image sources")
+	}
+
+	return s.absoluteURL(best.URL)
+}
+
+// absoluteURL resolves ref relative to the URL of the document, ref is returned as is if the URL is unknown
+func (s *Scraper) absoluteURL(ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("parse url [%s]: %w", ref, err)
+	}
+	if s.url == nil {
+		return u.String(), nil
+	}
+
+	return s.url.ResolveReference(u).String(), nil
+}
+
+func isHTMLSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f'
+}
@@ -0,0 +1,85 @@
+package scraper
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseSrcset(t *testing.T) {
+	tests := []struct {
+		name   string
+		srcset string
+		want   []ImageCandidate
+	}{
+		{
+			name:   "widths",
+			srcset: "small.jpg 480w, large.jpg 1080w",
+			want:   []ImageCandidate{{URL: "small.jpg", Width: 480}, {URL: "large.jpg", Width: 1080}},
+		},
+		{
+			name:   "densities and default",
+			srcset: " a.jpg,\n b.jpg 2x , c.jpg 1.5x",
+			want:   []ImageCandidate{{URL: "a.jpg", Density: 1}, {URL: "b.jpg", Density: 2}, {URL: "c.jpg", Density: 1.5}},
+		},
+		{
+			name:   "commas in url",
+			srcset: "https://cdn.test/w_100,h_100/a.jpg 100w, https://cdn.test/w_400,h_400/a.jpg 400w",
+			want:   []ImageCandidate{{URL: "https://cdn.test/w_100,h_100/a.jpg", Width: 100}, {URL: "https://cdn.test/w_400,h_400/a.jpg", Width: 400}},
+		},
+		{
+			name:   "invalid descriptors skipped",
+			srcset: "a.jpg 0w, b.jpg fast, c.jpg 300w",
+			want:   []ImageCandidate{{URL: "c.jpg", Width: 300}},
+		},
+		{
+			name:   "empty",
+			srcset: " , ",
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseSrcset(tt.srcset); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSrcset() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScraperImageURL(t *testing.T) {
+	s := newTestScraper(t, `<html><body>
+<img src="/thumb.jpg" srcset="/img-320.jpg 320w, /img-640.jpg 640w, /img-1280.jpg 1280w">
+<picture><source srcset="//cdn.test/p-800.webp 800w"><img src="p-200.jpg"></picture>
+<img data-src="lazy.jpg" data-srcset="lazy@2x.jpg 2x">
+<div></div>
+</body></html>`)
+	s.url, _ = url.Parse("https://shop.test/catalog/item")
+
+	tests := []struct {
+		name        string
+		path        string
+		targetWidth int
+		want        string
+		wantErr     bool
+	}{
+		{name: "largest", path: "/html/body/img[1]", want: "https://shop.test/img-1280.jpg"},
+		{name: "closest to target", path: "/html/body/img[1]", targetWidth: 500, want: "https://shop.test/img-640.jpg"},
+		{name: "target above all", path: "/html/body/img[1]", targetWidth: 2000, want: "https://shop.test/img-1280.jpg"},
+		{name: "picture sources", path: "/html/body/picture", want: "https://cdn.test/p-800.webp"},
+		{name: "lazy loading attributes", path: "/html/body/img[2]", want: "https://shop.test/catalog/lazy@2x.jpg"},
+		{name: "no sources", path: "/html/body/div", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ImageURL(tt.path, tt.targetWidth)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ImageURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ImageURL() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package scraper
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// contextCheckInterval is the number of siblings scanned between checks of the context
+const contextCheckInterval = 1024
+
+type (
+	// SelectorTimings collects time spent evaluating each path, safe for concurrent use and meant to be shared
+	// between scrapers of a run to find hotspots
+	SelectorTimings struct {
+		mu      sync.Mutex
+		timings map[string]SelectorTiming
+	}
+
+	SelectorTiming struct {
+		Path  string
+		Count int
+		Total time.Duration
+		Max   time.Duration
+	}
+)
+
+func NewSelectorTimings() *SelectorTimings {
+	return &SelectorTimings{timings: make(map[string]SelectorTiming)}
+}
+
+// WithSelectorTimings makes the Scraper add time spent evaluating paths to timings
+func WithSelectorTimings(timings *SelectorTimings) Option {
+	return func(o *options) error {
+		if timings == nil {
+			return errors.New("selector timings should be not nil")
+		}
+		o.timings = timings
+		return nil
+	}
+}
+
+// Timings returns timings of all evaluated paths, the slowest in total first
+func (t *SelectorTimings) Timings() []SelectorTiming {
+	t.mu.Lock()
+	timings := make([]SelectorTiming, 0, len(t.timings))
+	for _, timing := range t.timings {
+		timings = append(timings, timing)
+	}
+	t.mu.Unlock()
+
+	slices.SortFunc(timings, func(a, b SelectorTiming) int {
+		return cmp.Or(cmp.Compare(b.Total, a.Total), cmp.Compare(a.Path, b.Path))
+	})
+
+	return timings
+}
+
+func (t *SelectorTimings) track(path string, start time.Time) {
+	elapsed := time.Since(start)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timing := t.timings[path]
+	timing.Path = path
+	timing.Count++
+	timing.Total += elapsed
+	timing.Max = max(timing.Max, elapsed)
+	t.timings[path] = timing
+}
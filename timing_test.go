@@ -0,0 +1,54 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestScraperGetValueContext(t *testing.T) {
+	s := newTestScraper(t, "<html><body>"+strings.Repeat("<p>a</p>", 5000)+"<p>last</p></body></html>")
+
+	got, err := s.GetValueContext(context.Background(), "/html/body/p[5001]/text")
+	if err != nil || got != "last" {
+		t.Fatalf("GetValueContext() got = %v, error = %v", got, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = s.GetValueContext(ctx, "/html/body/p[5001]/text"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetValueContext() error = %v, want %v", err, context.Canceled)
+	}
+	if _, err = s.FindNodeContext(ctx, "/html/body/p[5001]"); !errors.Is(err, context.Canceled) {
+		t.Errorf("FindNodeContext() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestWithSelectorTimings(t *testing.T) {
+	timings := NewSelectorTimings()
+	s, err := New("https://someAddress", &httpClientWithoutError{}, WithSelectorTimings(timings))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	const titlePath = "/html/head/title/text"
+	for i := 0; i < 2; i++ {
+		_, _ = s.GetValue(titlePath)
+	}
+	_, _ = s.FindNode("/html/body")
+
+	got := timings.Timings()
+	if len(got) != 2 {
+		t.Fatalf("Timings() got %d paths, want 2", len(got))
+	}
+	for _, timing := range got {
+		wantCount := 1
+		if timing.Path == titlePath {
+			wantCount = 2
+		}
+		if timing.Count != wantCount || timing.Total < timing.Max {
+			t.Errorf("Timings() got = %+v", timing)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package scraper
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// TransportErrorKind is a category of a failed request attempt
+type TransportErrorKind int
+
+const (
+	TransportErrorOther TransportErrorKind = iota
+	TransportErrorDNS
+	TransportErrorConnect
+	TransportErrorTLS
+	TransportErrorTimeout
+)
+
+type (
+	// TransportError is a classified error of a request attempt, the final error of the retry client wraps it
+	TransportError struct {
+		Kind TransportErrorKind
+		// NotFound is set for DNS errors meaning that the host does not exist (NXDOMAIN)
+		NotFound bool
+		Err      error
+	}
+
+	// RetryPolicy decides whether an attempt failed with err is retried and how long to wait before it.
+	// backoff is the retryTimeout the client was created with.
+	RetryPolicy func(err *TransportError, backoff time.Duration) (bool, time.Duration)
+)
+
+func (k TransportErrorKind) String() string {
+	switch k {
+	case TransportErrorDNS:
+		return "dns"
+	case TransportErrorConnect:
+		return "connect"
+	case TransportErrorTLS:
+		return "tls"
+	case TransportErrorTimeout:
+		return "timeout"
+	default:
+		return "other"
+	}
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("%s error: %s", e.Kind, e.Err.Error())
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultRetryPolicy retries every error after backoff except DNS errors for hosts that do not exist
+func DefaultRetryPolicy(err *TransportError, backoff time.Duration) (bool, time.Duration) {
+	if err.Kind == TransportErrorDNS && err.NotFound {
+		return false, 0
+	}
+
+	return true, backoff
+}
+
+// ClassifyTransportError returns err as *TransportError, classifying it if it is not one already
+func ClassifyTransportError(err error) *TransportError {
+	if err == nil {
+		return nil
+	}
+
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		return transportErr
+	}
+
+	return &TransportError{Kind: transportErrorKind(err), NotFound: isHostNotFound(err), Err: err}
+}
+
+func transportErrorKind(err error) TransportErrorKind {
+	var (
+		dnsErr       *net.DNSError
+		recordErr    tls.RecordHeaderError
+		alertErr     tls.AlertError
+		verifyErr    *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		invalidErr   x509.CertificateInvalidError
+		hostnameErr  x509.HostnameError
+		opErr        *net.OpError
+		netErr       net.Error
+	)
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return TransportErrorDNS
+	case errors.As(err, &recordErr), errors.As(err, &alertErr), errors.As(err, &verifyErr),
+		errors.As(err, &authorityErr), errors.As(err, &invalidErr), errors.As(err, &hostnameErr):
+		return TransportErrorTLS
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return TransportErrorTimeout
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		return TransportErrorConnect
+	default:
+		return TransportErrorOther
+	}
+}
+
+func isHostNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
@@ -0,0 +1,144 @@
+package scraper
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClassifyTransportError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantKind     TransportErrorKind
+		wantNotFound bool
+	}{
+		{
+			name:         "dns not found",
+			err:          &url.Error{Op: "Get", URL: "https://nowhere", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "nowhere", IsNotFound: true}}},
+			wantKind:     TransportErrorDNS,
+			wantNotFound: true,
+		},
+		{
+			name:     "dns timeout",
+			err:      &net.DNSError{Err: "i/o timeout", Name: "slow", IsTimeout: true},
+			wantKind: TransportErrorDNS,
+		},
+		{
+			name:     "connection refused",
+			err:      &url.Error{Op: "Get", URL: "https://host", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}},
+			wantKind: TransportErrorConnect,
+		},
+		{
+			name:     "unknown authority",
+			err:      &url.Error{Op: "Get", URL: "https://host", Err: x509.UnknownAuthorityError{}},
+			wantKind: TransportErrorTLS,
+		},
+		{
+			name:     "deadline exceeded",
+			err:      &url.Error{Op: "Get", URL: "https://host", Err: context.DeadlineExceeded},
+			wantKind: TransportErrorTimeout,
+		},
+		{
+			name:     "other",
+			err:      errors.New("something went wrong"),
+			wantKind: TransportErrorOther,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyTransportError(tt.err)
+			if got.Kind != tt.wantKind {
+				t.Errorf("ClassifyTransportError() Kind = %v, want %v", got.Kind, tt.wantKind)
+			}
+			if got.NotFound != tt.wantNotFound {
+				t.Errorf("ClassifyTransportError() NotFound = %v, want %v", got.NotFound, tt.wantNotFound)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("ClassifyTransportError() does not wrap the original error")
+			}
+			if again := ClassifyTransportError(fmt.Errorf("wrapped: %w", got)); again != got {
+				t.Errorf("ClassifyTransportError() reclassified an already classified error")
+			}
+		})
+	}
+}
+
+func TestHTTPClientWithRetryPolicy(t *testing.T) {
+	target, _ := url.Parse("https://nowhere")
+
+	tests := []struct {
+		name      string
+		err       error
+		policy    RetryPolicy
+		wantCalls int
+		wantKind  TransportErrorKind
+	}{
+		{
+			name:      "fail fast on dns not found",
+			err:       &net.DNSError{Err: "no such host", Name: "nowhere", IsNotFound: true},
+			wantCalls: 1,
+			wantKind:  TransportErrorDNS,
+		},
+		{
+			name:      "retry connect errors",
+			err:       &net.OpError{Op: "dial", Err: errors.New("connection refused")},
+			wantCalls: 3,
+			wantKind:  TransportErrorConnect,
+		},
+		{
+			name: "custom policy",
+			err:  &net.OpError{Op: "dial", Err: errors.New("connection refused")},
+			policy: func(err *TransportError, _ time.Duration) (bool, time.Duration) {
+				return err.Kind != TransportErrorConnect, 0
+			},
+			wantCalls: 1,
+			wantKind:  TransportErrorConnect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			var opts []ClientOption
+			if tt.policy != nil {
+				opts = append(opts, WithRetryPolicy(tt.policy))
+			}
+			client, err := NewHTTPClientWithRetry(2, 0, opts...)
+			if err != nil {
+				t.Fatalf("NewHTTPClientWithRetry() error = %v", err)
+			}
+			client.(*httpClientWithRetry).client = &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+				calls++
+				return nil, tt.err
+			})}
+
+			_, err = client.Get(target)
+			var transportErr *TransportError
+			if !errors.As(err, &transportErr) {
+				t.Fatalf("Get() error = %v, want *TransportError", err)
+			}
+			if transportErr.Kind != tt.wantKind {
+				t.Errorf("Get() error kind = %v, want %v", transportErr.Kind, tt.wantKind)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Get() performed %d attempts, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+
+	if _, err := NewHTTPClientWithRetry(1, 0, WithRetryPolicy(nil)); err == nil {
+		t.Errorf("NewHTTPClientWithRetry() with nil retry policy expected error")
+	}
+}
@@ -0,0 +1,102 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/net/html"
+)
+
+type (
+	// TraversalLimits bounds traversals of NextAfter and GetChildes, zero values mean no limit.
+	// MaxDepth is the number of levels collected below the level of the starting node,
+	// MaxNodes is the number of collected nodes.
+	TraversalLimits struct {
+		MaxDepth int
+		MaxNodes int
+	}
+
+	// TruncatedError is returned together with the nodes collected so far when a traversal exceeds its limits.
+	// Subtrees deeper than MaxDepth are skipped and the traversal goes on, reaching MaxNodes stops it.
+	TruncatedError struct {
+		Limits TraversalLimits
+		// Depth reports whether subtrees were skipped because of MaxDepth
+		Depth bool
+		// Nodes reports whether the traversal was stopped because of MaxNodes
+		Nodes bool
+	}
+)
+
+func (e *TruncatedError) Error() string {
+	switch {
+	case e.Depth && e.Nodes:
+		return fmt.Sprintf("traversal truncated: exceeded max depth %d and max nodes %d", e.Limits.MaxDepth, e.Limits.MaxNodes)
+	case e.Nodes:
+		return fmt.Sprintf("traversal truncated: exceeded max nodes %d", e.Limits.MaxNodes)
+	default:
+		return fmt.Sprintf("traversal truncated: exceeded max depth %d", e.Limits.MaxDepth)
+	}
+}
+
+// WithTraversalLimits bounds traversals of NextAfter and GetChildes, which are unbounded by default
+func WithTraversalLimits(limits TraversalLimits) Option {
+	return func(o *options) error {
+		if limits.MaxDepth < 0 || limits.MaxNodes < 0 {
+			return errors.New("traversal limits should not be negative")
+		}
+		o.limits = limits
+		return nil
+	}
+}
+
+// collectAfter returns node, its following siblings and all their descendants in document order.
+// It returns the nodes collected so far and *TruncatedError if limits are exceeded.
+func collectAfter(node *html.Node, limits TraversalLimits) ([]*html.Node, error) {
+	type item struct {
+		node  *html.Node
+		depth int
+	}
+
+	var (
+		nodes     []*html.Node
+		truncated *TruncatedError
+		stack     []item
+	)
+	if node != nil {
+		stack = append(stack, item{node: node})
+	}
+
+	for len(stack) != 0 {
+		it := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if limits.MaxNodes != 0 && len(nodes) == limits.MaxNodes {
+			if truncated == nil {
+				truncated = &TruncatedError{Limits: limits}
+			}
+			truncated.Nodes = true
+			break
+		}
+		nodes = append(nodes, it.node)
+
+		if it.node.NextSibling != nil {
+			stack = append(stack, item{node: it.node.NextSibling, depth: it.depth})
+		}
+		if it.node.FirstChild != nil {
+			if limits.MaxDepth != 0 && it.depth == limits.MaxDepth {
+				if truncated == nil {
+					truncated = &TruncatedError{Limits: limits}
+				}
+				truncated.Depth = true
+				continue
+			}
+			stack = append(stack, item{node: it.node.FirstChild, depth: it.depth + 1})
+		}
+	}
+
+	if truncated != nil {
+		return nodes, truncated
+	}
+
+	return nodes, nil
+}
@@ -0,0 +1,103 @@
+package scraper
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestCollectAfterLimits(t *testing.T) {
+	s := newTestScraper(t, "<html><body><div><p>a<b>b</b></p><p>c</p></div><span>d</span></body></html>")
+	node, err := s.FindNode("/html/body/div")
+	if err != nil {
+		t.Fatalf("FindNode() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		limits    TraversalLimits
+		want      []string
+		wantDepth bool
+		wantNodes bool
+	}{
+		{
+			name:   "no limits",
+			limits: TraversalLimits{},
+			want:   []string{"div", "p", "a", "b", "b", "p", "c", "span", "d"},
+		},
+		{
+			name:      "max depth",
+			limits:    TraversalLimits{MaxDepth: 1},
+			want:      []string{"div", "p", "p", "span", "d"},
+			wantDepth: true,
+		},
+		{
+			name:      "max nodes",
+			limits:    TraversalLimits{MaxNodes: 3},
+			want:      []string{"div", "p", "a"},
+			wantNodes: true,
+		},
+		{
+			name:   "limits not reached",
+			limits: TraversalLimits{MaxDepth: 3, MaxNodes: 9},
+			want:   []string{"div", "p", "a", "b", "b", "p", "c", "span", "d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := collectAfter(node, tt.limits)
+
+			var got []string
+			for _, n := range nodes {
+				got = append(got, n.Data)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("collectAfter() got = %v, want %v", got, tt.want)
+			}
+
+			var truncated *TruncatedError
+			if errors.As(err, &truncated) != (tt.wantDepth || tt.wantNodes) {
+				t.Fatalf("collectAfter() error = %v", err)
+			}
+			if truncated != nil && (truncated.Depth != tt.wantDepth || truncated.Nodes != tt.wantNodes) {
+				t.Errorf("collectAfter() error = %+v, want depth %v and nodes %v", truncated, tt.wantDepth, tt.wantNodes)
+			}
+		})
+	}
+}
+
+func TestCollectAfterDeepDocument(t *testing.T) {
+	const depth = 100000
+	s := newTestScraper(t, "")
+	body, _ := s.FindNode("/html/body")
+	parent := body
+	for i := 0; i < depth; i++ {
+		child := &html.Node{Type: html.ElementNode, DataAtom: atom.Div, Data: "div"}
+		parent.AppendChild(child)
+		parent = child
+	}
+
+	nodes, err := s.GetChildes("/html/body")
+	if err != nil || len(nodes) != depth {
+		t.Errorf("GetChildes() got %d nodes, error = %v", len(nodes), err)
+	}
+}
+
+func TestWithTraversalLimits(t *testing.T) {
+	s, err := New("https://someAddress", &httpClientWithoutError{}, WithTraversalLimits(TraversalLimits{MaxNodes: 10}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	nodes, err := s.GetChildes("/html/body")
+	var truncated *TruncatedError
+	if !errors.As(err, &truncated) || len(nodes) != 10 {
+		t.Errorf("GetChildes() got %d nodes, error = %v", len(nodes), err)
+	}
+
+	if _, err = New("https://someAddress", &httpClientWithoutError{}, WithTraversalLimits(TraversalLimits{MaxDepth: -1})); err == nil {
+		t.Errorf("New() expected error for negative limits")
+	}
+}
@@ -0,0 +1,106 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUnknownVariant is returned by ExtractVariant when the layout of the document matches none of the rule sets
+var ErrUnknownVariant = errors.New("unknown layout variant")
+
+type (
+	// Variant is a group of fetches of a page with the same layout, Fingerprint is the one of its first fetch
+	Variant struct {
+		Fingerprint LayoutFingerprint
+		Count       int
+	}
+
+	// VariantRules are full XPaths of fields by name to extract from pages of the variant with Fingerprint
+	VariantRules struct {
+		Fingerprint LayoutFingerprint
+		Paths       map[string]string
+	}
+)
+
+// DetectVariants fetches webAddress the given number of times and groups the pages by layout with GroupVariants.
+// More than one variant means the site serves an A/B test, which can be pinned WithCookies if the variant is
+// assigned by a cookie, or handled with a rule set per variant by ExtractVariant.
+func DetectVariants(webAddress string, client HTTPClient, fetches, maxDistance int, opts ...Option) ([]Variant, error) {
+	if fetches <= 0 {
+		return nil, errors.New("fetches should be positive")
+	}
+
+	fingerprints := make([]LayoutFingerprint, 0, fetches)
+	for i := 0; i < fetches; i++ {
+		s, err := New(webAddress, client, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("fetch %d of [%s]: %w", i+1, webAddress, err)
+		}
+		fingerprints = append(fingerprints, s.Fingerprint())
+	}
+
+	return GroupVariants(fingerprints, maxDistance), nil
+}
+
+// GroupVariants groups fingerprints of fetches of a page into variants in the order they first appear.
+// A fingerprint belongs to the variant with the closest fingerprint at most maxDistance from it, so small changes
+// like an added banner stay in the same variant.
+func GroupVariants(fingerprints []LayoutFingerprint, maxDistance int) []Variant {
+	var variants []Variant
+	for _, f := range fingerprints {
+		i := closestVariant(len(variants), func(i int) LayoutFingerprint { return variants[i].Fingerprint }, f, maxDistance)
+		if i == -1 {
+			variants = append(variants, Variant{Fingerprint: f})
+			i = len(variants) - 1
+		}
+		variants[i].Count++
+	}
+
+	return variants
+}
+
+// ExtractVariant extracts fields with the rule set whose fingerprint is the closest to the fingerprint of the document
+// within maxDistance, ErrUnknownVariant is returned if there is none. Fields which could not be extracted are
+// missing from the result and their errors are joined into the returned error.
+func (s *Scraper) ExtractVariant(rules []VariantRules, maxDistance int) (map[string]string, error) {
+	i := closestVariant(len(rules), func(i int) LayoutFingerprint { return rules[i].Fingerprint }, s.Fingerprint(), maxDistance)
+	if i == -1 {
+		return nil, ErrUnknownVariant
+	}
+
+	names := make([]string, 0, len(rules[i].Paths))
+	for name := range rules[i].Paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	paths := make([]string, len(names))
+	for j, name := range names {
+		paths[j] = rules[i].Paths[name]
+	}
+
+	values := make(map[string]string, len(names))
+	var errs []error
+	for j, result := range s.GetValueBatch(paths) {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("extract field [%s]: %w", names[j], result.Err))
+			continue
+		}
+		values[names[j]] = result.Value
+	}
+
+	return values, errors.Join(errs...)
+}
+
+// closestVariant returns the index of the first of n fingerprints closest to f within maxDistance, -1 if there is none
+func closestVariant(n int, fingerprint func(i int) LayoutFingerprint, f LayoutFingerprint, maxDistance int) int {
+	closest := -1
+	for i := 0; i < n; i++ {
+		d := fingerprint(i).Distance(f)
+		if d <= maxDistance && (closest == -1 || d < fingerprint(closest).Distance(f)) {
+			closest = i
+		}
+	}
+
+	return closest
+}
@@ -0,0 +1,85 @@
+package scraper
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+const (
+	variantA = `<html><body><div><h1>Title</h1><span class="price">10 USD</span></div><ul><li>a</li><li>b</li></ul></body></html>`
+	variantB = `<html><body><table><tr><td><b>Title</b></td><td><i>10 USD</i></td></tr></table><form><input><button>go</button></form></body></html>`
+)
+
+// httpClientWithVariants serves its pages in turn to pages of any URL, like a site running an A/B test
+type httpClientWithVariants struct {
+	pages []string
+	calls int
+}
+
+func (c *httpClientWithVariants) Get(*url.URL) (*http.Response, error) {
+	page := c.pages[c.calls%len(c.pages)]
+	c.calls++
+
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(page))}, nil
+}
+
+func TestDetectVariants(t *testing.T) {
+	b, err := os.ReadFile("./test-data/correct.html.txt")
+	if err != nil {
+		t.Fatalf("read test page: %v", err)
+	}
+	page := string(b)
+	banner := strings.Replace(page, "<body >", "<body ><div><p><span>sale</span></p></div>", 1)
+	client := &httpClientWithVariants{pages: []string{page, variantB, banner, page}}
+
+	got, err := DetectVariants("https://shop.test/", client, 4, 16)
+	if err != nil {
+		t.Fatalf("DetectVariants() error = %v", err)
+	}
+	want := []Variant{
+		{Fingerprint: newTestScraper(t, page).Fingerprint(), Count: 3},
+		{Fingerprint: newTestScraper(t, variantB).Fingerprint(), Count: 1},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("DetectVariants() got = %+v, want %+v", got, want)
+	}
+
+	if _, err = DetectVariants("https://shop.test/", client, 0, 16); err == nil {
+		t.Errorf("DetectVariants() expected error for no fetches")
+	}
+}
+
+func TestScraperExtractVariant(t *testing.T) {
+	rules := []VariantRules{
+		{
+			Fingerprint: newTestScraper(t, variantA).Fingerprint(),
+			Paths:       map[string]string{"title": "/html/body/div/h1/text", "price": "/html/body/div/span/text"},
+		},
+		{
+			Fingerprint: newTestScraper(t, variantB).Fingerprint(),
+			Paths:       map[string]string{"title": "//td/b/text", "price": "//td/i/text", "stock": "//td/em/text"},
+		},
+	}
+
+	got, err := newTestScraper(t, variantA).ExtractVariant(rules, 16)
+	if err != nil || got["title"] != "Title" || got["price"] != "10 USD" {
+		t.Errorf("ExtractVariant() of variant A got = %v, error = %v", got, err)
+	}
+
+	got, err = newTestScraper(t, variantB).ExtractVariant(rules, 16)
+	if err == nil || !strings.Contains(err.Error(), "[stock]") {
+		t.Errorf("ExtractVariant() of variant B error = %v, want error of field stock", err)
+	}
+	if got["title"] != "Title" || got["price"] != "10 USD" || len(got) != 2 {
+		t.Errorf("ExtractVariant() of variant B got = %v", got)
+	}
+
+	if _, err = newTestScraper(t, "<p>blocked</p>").ExtractVariant(rules, 2); !errors.Is(err, ErrUnknownVariant) {
+		t.Errorf("ExtractVariant() of unknown layout error = %v, want ErrUnknownVariant", err)
+	}
+}